@@ -13,6 +13,15 @@ const (
 	AccountTypeHomeLoan      AccountType = "HOME_LOAN"     // A home_loan account.
 )
 
+// IsKnown reports whether t is one of the account types documented by Up.
+func (t AccountType) IsKnown() bool {
+	switch t {
+	case AccountTypeSaver, AccountTypeTransactional, AccountTypeHomeLoan:
+		return true
+	}
+	return false
+}
+
 // AccountOwnershipType represents the type of ownership for an account.
 type AccountOwnershipType string
 
@@ -21,6 +30,15 @@ const (
 	AccountOwnershipTypeJoint      AccountOwnershipType = "JOINT"      // An account owned by multiple people.
 )
 
+// IsKnown reports whether t is one of the ownership types documented by Up.
+func (t AccountOwnershipType) IsKnown() bool {
+	switch t {
+	case AccountOwnershipTypeIndividual, AccountOwnershipTypeJoint:
+		return true
+	}
+	return false
+}
+
 // AccountResource defines the core details of an account.
 type AccountResource struct {
 	DisplayName   string               `json:"displayName"`
@@ -7,8 +7,8 @@ import (
 	"strconv"
 	"strings"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // AccountsPaginationWrapper is a pagination wrapper for a slice of AccountDataWrapper.
@@ -36,39 +36,56 @@ func ListAccountsOptionFilterAccountOwnershipType(t AccountOwnershipType) ListAc
 	return ListAccountsOption{newListOption("filter[ownershipType]", string(t))}
 }
 
-// ListAccounts returns all accounts for the authenticated user.
-// https://developer.up.com.au/#get_accounts.
-func (c *Client) ListAccounts(
+// listAccounts is the shared paginated fetch used by ListAccounts and anything
+// else that needs the full account data (eg. the account ID).
+func (c *Client) listAccounts(
 	ctx context.Context,
-	opts ...ListAccountsOption,
-) (accounts []AccountResource, err error) {
-
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "ListAccounts")
-	defer span.End()
+	options []ListAccountsOption,
+) (accounts []AccountDataWrapper, err error) {
 
 	sr := senderRequest{
 		method:  http.MethodGet,
 		path:    "/accounts",
-		queries: setupQueries(opts),
+		queries: setupQueries(options),
 	}
 
-	for {
+	trace.SpanFromContext(ctx).SetAttributes(queryAttributes(sr.queries)...)
+	for page := 1; ; page++ {
+		sr.page = page
 		var resp AccountsPaginationWrapper
-		if _, err := c.sender(newCtx, sr, &resp); err != nil {
-			span.SetStatus(codes.Error, fmt.Sprintf("failed to list accounts: %v", err))
-			span.RecordError(err)
+		if _, err := c.sender(ctx, sr, &resp); err != nil {
 			return nil, fmt.Errorf("listing accounts: %w", err)
 		}
-		for _, a := range resp.Data {
-			accounts = append(accounts, a.Attributes)
-		}
+		accounts = append(accounts, resp.Data...)
 		if resp.Links.Next == "" {
+			c.recordList(ctx, page, len(accounts))
 			break
 		}
 		sr.path = strings.Replace(resp.Links.Next, c.endpoint, "", 1)
 		sr.queries = nil
 	}
+	return accounts, nil
+}
+
+// ListAccounts returns all accounts for the authenticated user.
+// https://developer.up.com.au/#get_accounts.
+func (c *Client) ListAccounts(
+	ctx context.Context,
+	opts ...ListAccountsOption,
+) (accounts []AccountResource, err error) {
 
+	newCtx, span := c.startOperation(ctx, "ListAccounts")
+	defer span.End()
+
+	data, err := c.listAccounts(newCtx, opts)
+	if err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to list accounts: %v", err))
+		span.RecordError(err)
+		return nil, err
+	}
+	for _, a := range data {
+		accounts = append(accounts, a.Attributes)
+	}
 	return accounts, nil
 }
 
@@ -76,7 +93,7 @@ func (c *Client) ListAccounts(
 // https://developer.up.com.au/#get_accounts_id.
 func (c *Client) GetAccount(ctx context.Context, id string) (*AccountResource, error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "GetAccount")
+	newCtx, span := c.startOperation(ctx, "GetAccount", resourceIDAttribute(id))
 	defer span.End()
 
 	var resp struct {
@@ -0,0 +1,424 @@
+package up
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// AnonymiserOption configures an Anonymiser.
+type AnonymiserOption func(a *Anonymiser)
+
+// AnonymiserOptionSeed sets the seed that IDs, names, and the date and amount
+// shifts, are derived from. Payloads anonymised with the same seed share the
+// same mapping, so relationships between them stay valid. Defaults to 0.
+func AnonymiserOptionSeed(seed uint64) AnonymiserOption {
+	return func(a *Anonymiser) {
+		a.seed = seed
+	}
+}
+
+// AnonymiserOptionDateShift sets the bounds dates are shifted within; every
+// date is shifted by the same amount, between -max and max, so the order and
+// gaps between them are kept. Defaults to 30 days.
+func AnonymiserOptionDateShift(max time.Duration) AnonymiserOption {
+	return func(a *Anonymiser) {
+		a.maxDateShift = max
+	}
+}
+
+// AnonymiserOptionAmountScale sets the bounds amounts are scaled within; every
+// amount is scaled by the same factor, between 1-max and 1+max, so amounts
+// that matched (eg. a transaction's amount and hold amount) still do. max is
+// clamped to between 0 and 0.99, so an amount's sign is kept. Defaults to 0.2.
+func AnonymiserOptionAmountScale(max float64) AnonymiserOption {
+	return func(a *Anonymiser) {
+		a.maxAmountScale = max
+	}
+}
+
+// AnonymiserOptionEndpoint sets the endpoint links are rewritten to use.
+// Defaults to https://api.up.com.au/api/v1.
+func AnonymiserOptionEndpoint(endpoint string) AnonymiserOption {
+	return func(a *Anonymiser) {
+		a.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// ---
+
+// Fake values used in place of free text.
+var (
+	anonymisedMerchants = []string{"Acme Groceries", "Corner Cafe", "Northside Hardware", "Bluebird Books", "Harbour Fuel", "Summit Pharmacy", "Lantern Noodle Bar", "Metro Transit", "Parkside Bakery", "Orbit Electronics"}
+	anonymisedPeople    = []string{"Alex", "Sam", "Jordan", "Casey", "Riley", "Morgan"}
+	anonymisedMessages  = []string{"Thanks!", "Dinner", "Rent", "Shared costs", "Birthday present", "Reimbursement"}
+)
+
+// apiCollections are the collections in the API's paths, each named after the
+// type of the resources in it.
+var apiCollections = []string{"accounts", "attachments", "categories", "tags", "transactions", "webhooks"}
+
+// Anonymiser scrubs API payloads (eg. responses to commit as testdata) of
+// anything identifying, while keeping them valid: IDs are remapped, free text
+// (eg. descriptions, names, notes) is faked, links are rewritten, and dates
+// and amounts are shifted. Category IDs, which are the same for everyone, are
+// kept. The mapping is derived from the seed, so is stable across payloads,
+// and an Anonymiser is safe to use concurrently.
+type Anonymiser struct {
+	seed           uint64
+	maxDateShift   time.Duration
+	maxAmountScale float64
+	endpoint       string
+
+	// derived from the seed.
+	dateShift   time.Duration
+	amountScale float64
+}
+
+// NewAnonymiser returns a new Anonymiser.
+func NewAnonymiser(options ...AnonymiserOption) *Anonymiser {
+	a := &Anonymiser{
+		maxDateShift:   30 * 24 * time.Hour,
+		maxAmountScale: 0.2,
+		endpoint:       "https://api.up.com.au/api/v1",
+	}
+	for _, o := range options {
+		o(a)
+	}
+	a.maxAmountScale = min(max(a.maxAmountScale, 0), 0.99)
+	if a.maxDateShift > 0 {
+		a.dateShift = time.Duration(a.hash("date-shift", "")%uint64(2*a.maxDateShift/time.Second+1))*time.Second - a.maxDateShift
+	}
+	a.amountScale = 1 + a.maxAmountScale*(float64(a.hash("amount-scale", "")%2001)/1000-1)
+	return a
+}
+
+// Anonymise returns the given JSON payload, anonymised. The payload's
+// structure, including the order of its fields, is kept.
+func (a *Anonymiser) Anonymise(payload []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	v, err := decodeOrderedJSON(dec)
+	if err != nil {
+		return nil, ErrFailedUnmarshal{err}
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, ErrFailedUnmarshal{fmt.Errorf("unexpected data after the payload")}
+	}
+	v = a.value(v, "", "")
+	var compact, indented bytes.Buffer
+	if err := encodeOrderedJSON(&compact, v); err != nil {
+		return nil, ErrFailedMarshal{err}
+	}
+	if err := json.Indent(&indented, compact.Bytes(), "", "  "); err != nil {
+		return nil, ErrFailedMarshal{err}
+	}
+	return append(indented.Bytes(), '\n'), nil
+}
+
+// value returns the given decoded JSON value, found under the given key in an
+// object of the given parent key, anonymised.
+func (a *Anonymiser) value(v interface{}, key, parent string) interface{} {
+	switch v := v.(type) {
+	case *orderedJSONObject:
+		return a.object(v, key)
+	case []interface{}:
+		for i, e := range v {
+			v[i] = a.value(e, key, parent)
+		}
+		return v
+	case string:
+		return a.string(v, key, parent)
+	}
+	return v
+}
+
+// object anonymises the given object, found under the given key, in place.
+func (a *Anonymiser) object(o *orderedJSONObject, key string) interface{} {
+	if a.money(o) {
+		return o
+	}
+	resourceType, _ := o.get("type").(string)
+	for i, f := range o.fields {
+		if id, ok := f.value.(string); ok && f.key == "id" {
+			o.fields[i].value = a.id(resourceType, id)
+			continue
+		}
+		o.fields[i].value = a.value(f.value, f.key, key)
+	}
+	return o
+}
+
+// money scales the given object if it's Money, reporting whether it was. The
+// value and base units are scaled separately, so any inconsistency between
+// them (eg. the bug being captured) is kept.
+func (a *Anonymiser) money(o *orderedJSONObject) bool {
+	currencyCode, ok := o.get("currencyCode").(string)
+	units, ok2 := o.get("valueInBaseUnits").(json.Number)
+	if !ok || !ok2 {
+		return false
+	}
+	if n, err := units.Int64(); err == nil {
+		o.set("valueInBaseUnits", json.Number(fmt.Sprint(a.scale(n))))
+	}
+	exponent := currencyExponent(currencyCode)
+	if value, ok := o.get("value").(string); ok {
+		if n, err := parseBaseUnits(value, exponent); err == nil {
+			o.set("value", formatBaseUnits(a.scale(n), exponent, ""))
+		}
+	}
+	return true
+}
+
+// scale returns the given base units scaled by the Anonymiser's amount scale;
+// an amount that isn't zero isn't scaled to zero, so its sign is kept.
+func (a *Anonymiser) scale(units int64) int64 {
+	scaled := int64(math.Round(float64(units) * a.amountScale))
+	switch {
+	case scaled == 0 && units > 0:
+		return 1
+	case scaled == 0 && units < 0:
+		return -1
+	}
+	return scaled
+}
+
+// string returns the given string, found under the given key in an object of
+// the given parent key, anonymised.
+func (a *Anonymiser) string(s, key, parent string) string {
+	switch key {
+	case "self", "related", "next", "prev":
+		return a.link(s)
+	case "description", "rawText":
+		merchant := anonymisedMerchants[a.hash("merchant", s)%uint64(len(anonymisedMerchants))]
+		if key == "rawText" {
+			return strings.ToUpper(merchant) + " SYDNEY"
+		}
+		return merchant
+	case "message", "text":
+		return anonymisedMessages[a.hash("message", s)%uint64(len(anonymisedMessages))]
+	case "displayName":
+		if parent == "performingCustomer" {
+			return anonymisedPeople[a.hash("person", s)%uint64(len(anonymisedPeople))]
+		}
+		return "Account " + a.hex("account-name", s, 4)
+	case "cardNumberSuffix":
+		return fmt.Sprintf("%04d", a.hash("card", s)%10000)
+	case "deepLinkURL":
+		return "up://transaction/" + base64.StdEncoding.EncodeToString([]byte(a.hex("deep-link", s, 8)))
+	case "url", "fileURL":
+		return "https://example.com/" + a.hex("url", s, 16)
+	case "secretKey":
+		return a.hex("secret", s, 64)
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.Add(a.dateShift).Format(dateLayout(s))
+	}
+	return s
+}
+
+// dateLayout returns the layout of the given RFC 3339 date, so a shifted date
+// keeps its precision (eg. trailing zeros in its fractional seconds) and how
+// its offset is written.
+func dateLayout(s string) string {
+	layout := "2006-01-02T15:04:05"
+	if len(s) > len(layout) && s[len(layout)] == '.' {
+		digits := len(s[len(layout)+1:]) - len(strings.TrimLeft(s[len(layout)+1:], "0123456789"))
+		layout += "." + strings.Repeat("0", digits)
+	}
+	if strings.HasSuffix(s, "Z") {
+		return layout + "Z07:00"
+	}
+	return layout + "-07:00"
+}
+
+// id returns the given ID, of a resource of the given type, remapped.
+func (a *Anonymiser) id(resourceType, id string) string {
+	switch resourceType {
+	case "categories":
+		return id
+	case "tags":
+		return "tag-" + a.hex("tag", id, 6)
+	}
+	h := a.hex("id", id, 32)
+	return h[:8] + "-" + h[8:12] + "-4" + h[13:16] + "-8" + h[17:20] + "-" + h[20:32]
+}
+
+// link returns the given link to the API rewritten to the Anonymiser's
+// endpoint, with the IDs, and filters, in it anonymised.
+func (a *Anonymiser) link(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() {
+		return s
+	}
+	path := u.Path
+	if i := strings.Index(path, "/api/v1"); i != -1 {
+		path = path[i+len("/api/v1"):]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i++ {
+		if slices.Contains(apiCollections, segments[i-1]) {
+			segments[i] = a.id(segments[i-1], segments[i])
+		}
+	}
+	link := a.endpoint + "/" + strings.Join(segments, "/")
+	if u.RawQuery == "" {
+		return link
+	}
+	q := u.Query()
+	for k, vs := range q {
+		for i, v := range vs {
+			switch k {
+			case "page[after]", "page[before]":
+				vs[i] = base64.StdEncoding.EncodeToString([]byte(a.hex("cursor", v, 16)))
+			case "filter[tag]":
+				vs[i] = a.id("tags", v)
+			case "filter[since]", "filter[until]":
+				vs[i] = a.string(v, "", "")
+			}
+		}
+	}
+	return link + "?" + q.Encode()
+}
+
+// hash returns a hash of the given value, of the given kind, with the seed.
+func (a *Anonymiser) hash(kind, v string) uint64 {
+	return binary.BigEndian.Uint64(a.sum(kind, v))
+}
+
+// hex returns n hex characters of a hash of the given value, of the given
+// kind, with the seed.
+func (a *Anonymiser) hex(kind, v string, n int) string {
+	return hex.EncodeToString(a.sum(kind, v))[:n]
+}
+
+// sum returns the SHA-256 of the given value, of the given kind, with the
+// seed.
+func (a *Anonymiser) sum(kind, v string) []byte {
+	h := sha256.New()
+	binary.Write(h, binary.BigEndian, a.seed)
+	io.WriteString(h, kind+"\x00"+v)
+	return h.Sum(nil)
+}
+
+// ---
+
+// orderedJSONObject is a decoded JSON object that keeps the order of its
+// fields.
+type orderedJSONObject struct {
+	fields []orderedJSONField
+}
+
+type orderedJSONField struct {
+	key   string
+	value interface{}
+}
+
+// get returns the value of the given field, or nil.
+func (o *orderedJSONObject) get(key string) interface{} {
+	for _, f := range o.fields {
+		if f.key == key {
+			return f.value
+		}
+	}
+	return nil
+}
+
+// set sets the value of the given, existing, field.
+func (o *orderedJSONObject) set(key string, value interface{}) {
+	for i, f := range o.fields {
+		if f.key == key {
+			o.fields[i].value = value
+		}
+	}
+}
+
+// decodeOrderedJSON decodes the next JSON value from the given decoder, with
+// objects decoded as *orderedJSONObject.
+func decodeOrderedJSON(dec *json.Decoder) (interface{}, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case json.Delim('{'):
+		o := &orderedJSONObject{}
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			o.fields = append(o.fields, orderedJSONField{k.(string), v})
+		}
+		_, err := dec.Token()
+		return o, err
+	case json.Delim('['):
+		s := []interface{}{}
+		for dec.More() {
+			v, err := decodeOrderedJSON(dec)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		_, err := dec.Token()
+		return s, err
+	}
+	return t, nil
+}
+
+// encodeOrderedJSON writes the given value, decoded by decodeOrderedJSON, as
+// compact JSON.
+func encodeOrderedJSON(w *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case *orderedJSONObject:
+		w.WriteByte('{')
+		for i, f := range v.fields {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := encodeOrderedJSON(w, f.key); err != nil {
+				return err
+			}
+			w.WriteByte(':')
+			if err := encodeOrderedJSON(w, f.value); err != nil {
+				return err
+			}
+		}
+		w.WriteByte('}')
+		return nil
+	case []interface{}:
+		w.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			if err := encodeOrderedJSON(w, e); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+		return nil
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	w.Truncate(w.Len() - 1) // Encode adds a newline.
+	return nil
+}
@@ -0,0 +1,183 @@
+package up
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_Anonymiser(t *testing.T) {
+	a := NewAnonymiser(AnonymiserOptionSeed(7), AnonymiserOptionEndpoint("https://up.example.com/api/v1"))
+	tests := map[string]struct {
+		content []byte
+		v       interface{}
+		absent  []string
+	}{
+		"transaction": {
+			content: transactionTestdata.content,
+			v:       &Wrapper[TransactionDataWrapper]{},
+			absent:  []string{"6fff09f5-be7d-4ae1-9f71-4a25440bc405", "Warung Bebek Bengil", "WARUNG BEBEK", "Bobby", "-107.92", "2024-11-03", "api.up.com.au"},
+		},
+		"transactions": {
+			content: transactionsTestdata[0].content,
+			v:       &WrapperSlice[TransactionDataWrapper]{},
+			absent:  []string{"David Taylor", "Money for the pizzas last night.", "api.up.com.au"},
+		},
+		"accounts": {
+			content: accountsTestdata[0].content,
+			v:       &WrapperSlice[AccountDataWrapper]{},
+			absent:  []string{"4ed1d99c-ce10-4b54-952f-05151c2ab423", "api.up.com.au"},
+		},
+		"tags": {
+			content: tagsTestdata[0].content,
+			v:       &TagsPaginationWrapper{},
+			absent:  []string{"Holiday", "Pizza Night", "api.up.com.au"},
+		},
+		"categories": {
+			content: categoriesTestdata.content,
+			v:       &CategoryPaginationWrapper{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := a.Anonymise(tt.content)
+			if err != nil {
+				t.Fatalf("Anonymise() returned an error; error=%v", err)
+			}
+			for _, s := range tt.absent {
+				if bytes.Contains(got, []byte(s)) {
+					t.Errorf("Anonymise() left %q in the payload", s)
+				}
+			}
+
+			// the payload still decodes, as cleanly as it did before.
+			want, err := DiffSchema(tt.content, tt.v)
+			if err != nil {
+				t.Fatalf("failed to decode payload; error=%v", err)
+			}
+			issues, err := DiffSchema(got, tt.v)
+			if err != nil {
+				t.Fatalf("failed to decode anonymised payload; error=%v", err)
+			}
+			if len(issues) != len(want) {
+				t.Errorf("anonymised payload has unexpected issues;\nwant=%v\ngot=%v\n", want, issues)
+			}
+
+			// the same seed anonymises the same way; a different one doesn't.
+			if again, _ := NewAnonymiser(AnonymiserOptionSeed(7), AnonymiserOptionEndpoint("https://up.example.com/api/v1")).Anonymise(tt.content); !bytes.Equal(again, got) {
+				t.Errorf("Anonymise() isn't stable for the same seed")
+			}
+			if other, _ := NewAnonymiser(AnonymiserOptionSeed(8)).Anonymise(tt.content); bytes.Equal(other, got) && len(tt.absent) > 0 {
+				t.Errorf("Anonymise() is the same for different seeds")
+			}
+		})
+	}
+}
+
+func Test_Anonymiser_relationships(t *testing.T) {
+	a := NewAnonymiser(
+		AnonymiserOptionDateShift(48*time.Hour),
+		AnonymiserOptionAmountScale(0.1),
+	)
+
+	// an ID is mapped the same way wherever it appears.
+	account, err := a.Anonymise([]byte(`{"data":{"type":"accounts","id":"acc-1","links":{"self":"https://api.up.com.au/api/v1/accounts/acc-1"}}}`))
+	if err != nil {
+		t.Fatalf("Anonymise() returned an error; error=%v", err)
+	}
+	txn, err := a.Anonymise([]byte(`{"data":{"type":"transactions","id":"txn-1","attributes":{"createdAt":"2024-01-01T10:00:00+11:00","amount":{"currencyCode":"AUD","value":"-10.00","valueInBaseUnits":-1000},"holdInfo":{"amount":{"currencyCode":"AUD","value":"-10.00","valueInBaseUnits":-1000}}},"relationships":{"account":{"data":{"type":"accounts","id":"acc-1"}},"category":{"data":{"type":"categories","id":"groceries"}},"tags":{"data":[{"type":"tags","id":"Holiday"}],"links":{"self":"https://api.up.com.au/api/v1/transactions?filter%5Btag%5D=Holiday"}}}}}`))
+	if err != nil {
+		t.Fatalf("Anonymise() returned an error; error=%v", err)
+	}
+	var (
+		gotAccount Wrapper[AccountDataWrapper]
+		gotTxn     Wrapper[TransactionDataWrapper]
+	)
+	if err := json.Unmarshal(account, &gotAccount); err != nil {
+		t.Fatalf("failed to decode anonymised account; error=%v", err)
+	}
+	if err := json.Unmarshal(txn, &gotTxn); err != nil {
+		t.Fatalf("failed to decode anonymised transaction; error=%v", err)
+	}
+	if id := gotAccount.Data.ID; id == "acc-1" || id != gotTxn.Data.Relationships.Account.Data.ID {
+		t.Errorf("account ID wasn't mapped consistently; account=%v, relationship=%v", id, gotTxn.Data.Relationships.Account.Data.ID)
+	}
+	if self := gotAccount.Data.Links.Self; !strings.HasSuffix(self, "/accounts/"+gotAccount.Data.ID) {
+		t.Errorf("account link wasn't rewritten; got=%v", self)
+	}
+	if got := gotTxn.Data.Relationships.Category.Data.ID; got != "groceries" {
+		t.Errorf("category ID was changed; got=%v", got)
+	}
+	tag := gotTxn.Data.Relationships.Tags.Data[0].ID
+	if tag == "Holiday" || !strings.Contains(gotTxn.Data.Relationships.Tags.Links.Self, "filter%5Btag%5D="+tag) {
+		t.Errorf("tag wasn't mapped consistently; tag=%v, link=%v", tag, gotTxn.Data.Relationships.Tags.Links.Self)
+	}
+
+	// dates and amounts are shifted within bounds, consistently.
+	created := time.Date(2024, 1, 1, 10, 0, 0, 0, time.FixedZone("", 11*60*60))
+	if shift := gotTxn.Data.Attributes.CreatedAt.Sub(created).Abs(); shift > 48*time.Hour {
+		t.Errorf("date was shifted too far; shift=%v", shift)
+	}
+	amount, hold := gotTxn.Data.Attributes.Amount, gotTxn.Data.Attributes.HoldInfo.Amount
+	if amount != hold {
+		t.Errorf("amounts weren't scaled consistently; amount=%v, hold=%v", amount, hold)
+	}
+	if amount.ValueInBaseUnits < -1100 || amount.ValueInBaseUnits > -900 {
+		t.Errorf("amount was scaled too far; got=%v", amount.ValueInBaseUnits)
+	}
+	if err := amount.Validate(); err != nil {
+		t.Errorf("amount is invalid; error=%v", err)
+	}
+}
+
+func Test_Anonymiser_shapes(t *testing.T) {
+	tests := map[string]struct {
+		options []AnonymiserOption
+		payload string
+		want    string // A regexp the anonymised payload must match.
+		err     bool
+	}{
+		"fractional seconds are kept": {
+			payload: `{"createdAt":"2024-01-01T10:00:00.120+11:00"}`,
+			want:    `"createdAt": "\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.120\+11:00"`,
+		},
+		"utc is kept": {
+			payload: `{"createdAt":"2024-01-01T10:00:00Z"}`,
+			want:    `"createdAt": "\d{4}-\d\d-\d\dT\d\d:\d\d:\d\dZ"`,
+		},
+		"debits stay debits": {
+			options: []AnonymiserOption{AnonymiserOptionSeed(3), AnonymiserOptionAmountScale(1.5)},
+			payload: `{"amount":{"currencyCode":"AUD","value":"-10.00","valueInBaseUnits":-1000}}`,
+			want:    `"value": "-\d+\.\d\d",\s+"valueInBaseUnits": -\d+`,
+		},
+		"credits stay credits": {
+			options: []AnonymiserOption{AnonymiserOptionSeed(3), AnonymiserOptionAmountScale(1.5)},
+			payload: `{"amount":{"currencyCode":"AUD","value":"0.01","valueInBaseUnits":1}}`,
+			want:    `"value": "0\.01",\s+"valueInBaseUnits": 1`,
+		},
+		"trailing data": {
+			payload: `{"a":1} trailing`,
+			err:     true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewAnonymiser(tt.options...).Anonymise([]byte(tt.payload))
+			if tt.err {
+				if _, ok := err.(ErrFailedUnmarshal); !ok {
+					t.Errorf("Anonymise() returned an unexpected error; want=ErrFailedUnmarshal, got=%v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Anonymise() returned an error; error=%v", err)
+			}
+			if !regexp.MustCompile(tt.want).Match(got) {
+				t.Errorf("Anonymise() returned an unexpected payload; want=%v, got=%s", tt.want, got)
+			}
+		})
+	}
+}
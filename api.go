@@ -0,0 +1,46 @@
+package up
+
+import (
+	"context"
+	"net/url"
+)
+
+// API is the interface of every operation a Client performs against the API,
+// for code that wants to accept something other than a *Client - eg. the fake
+// in the upmock package. Methods that configure or derive a Client (eg. With,
+// NewHydrator) aren't part of it.
+type API interface {
+
+	// util.
+	Ping(ctx context.Context) (*Ping, error)
+	Do(ctx context.Context, method string, path string, query url.Values, body interface{}, out interface{}) error
+	RateLimitStatus() RateLimitStatus
+
+	// accounts.
+	ListAccounts(ctx context.Context, opts ...ListAccountsOption) ([]AccountResource, error)
+	GetAccount(ctx context.Context, id string) (*AccountResource, error)
+
+	// transactions.
+	ListTransactions(ctx context.Context, options ...ListTransactionsOption) ([]TransactionDataWrapper, error)
+	ListTransactionsByAccount(ctx context.Context, accountID string, options ...ListTransactionsOption) ([]TransactionDataWrapper, error)
+	ListTransactionsHydrated(ctx context.Context, options ...ListTransactionsOption) ([]HydratedTransaction, error)
+	GetTransaction(ctx context.Context, id string) (*TransactionDataWrapper, error)
+	GetTransactions(ctx context.Context, ids []string, options ...GetTransactionsOption) (*GetTransactionsResult, error)
+
+	// categories.
+	ListCategories(ctx context.Context) ([]CategoryData, error)
+	GetCategory(ctx context.Context, id string) (*CategoryData, error)
+	SetTransactionCategory(ctx context.Context, transactionID string, categoryID string) error
+
+	// tags.
+	ListTags(ctx context.Context, opts ...ListTagsOption) ([]TagResource, error)
+	AddTagsToTransaction(ctx context.Context, id string, tags []string) error
+	RemoveTagsFromTransaction(ctx context.Context, id string, tags []string) error
+	BulkTag(ctx context.Context, req BulkTagRequest, options ...BulkTagOption) (*BulkTagReport, error)
+	RenameTag(ctx context.Context, from string, to string, options ...BulkTagOption) (*BulkTagReport, error)
+	MergeTags(ctx context.Context, sources []string, target string, options ...BulkTagOption) (*BulkTagReport, error)
+	TagStats(ctx context.Context, options ...ListTransactionsOption) ([]TagStats, error)
+}
+
+// Client must implement API; a method added to one must be added to the other.
+var _ API = (*Client)(nil)
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"net/http"
 
-	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 )
 
@@ -17,10 +17,12 @@ type CategoryAttributes struct {
 // CategoryRelationships defines the parent/child relationships for a category.
 type CategoryRelationships struct {
 	Parent struct {
-		Data *Object `json:"data"` // nil for top-level categories
+		Data  *Object `json:"data"` // nil for top-level categories
+		Links Links   `json:"links"`
 	} `json:"parent"`
 	Children struct {
-		Data []Object `json:"data"`
+		Data  []Object `json:"data"`
+		Links Links    `json:"links"`
 	} `json:"children"`
 }
 
@@ -44,7 +46,7 @@ type categoryRef struct {
 // https://developer.up.com.au/#get_categories.
 func (c *Client) ListCategories(ctx context.Context) ([]CategoryData, error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "ListCategories")
+	newCtx, span := c.startOperation(ctx, "ListCategories")
 	defer span.End()
 
 	var resp CategoryPaginationWrapper
@@ -68,7 +70,10 @@ func (c *Client) SetTransactionCategory(
 	categoryID string,
 ) error {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "SetTransactionCategory")
+	newCtx, span := c.startOperation(ctx, "SetTransactionCategory",
+		resourceIDAttribute(transactionID),
+		attribute.String("up.category.id", categoryID),
+	)
 	defer span.End()
 
 	var body setCategoryBody
@@ -93,7 +98,7 @@ func (c *Client) SetTransactionCategory(
 // https://developer.up.com.au/#get_categories_id.
 func (c *Client) GetCategory(ctx context.Context, id string) (*CategoryData, error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "GetCategory")
+	newCtx, span := c.startOperation(ctx, "GetCategory", resourceIDAttribute(id))
 	defer span.End()
 
 	var resp struct {
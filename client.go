@@ -4,10 +4,11 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
-	"os"
 	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // An iHttpClient is an interface over http.Client.
@@ -19,35 +20,40 @@ type iHttpClient interface {
 type Client struct {
 
 	// tracing.
-	tracerName string // The name of the tracer output in the traces.
+	tracerName     string               // The name of the tracer output in the traces.
+	tracerProvider trace.TracerProvider // The provider of the tracer spans are started with.
+	meterProvider  metric.MeterProvider // The provider of the meter that metrics are recorded with.
+	metrics        *clientMetrics       // The instruments metrics are recorded with.
 
 	// config.
-	endpoint      string      // The endpoint to query against.
-	httpClient    iHttpClient // The http client used when sending / receiving data from the endpoint.
-	headers       http.Header // The headers passed to the http client when sending / receiving data from the endpoint.
-	skipAuthCheck bool        // Skip the Ping call on startup (useful when API is unreachable).
+	endpoint      string             // The endpoint to query against.
+	httpClient    iHttpClient        // The http client used when sending / receiving data from the endpoint.
+	transport     *http.Transport    // The transport used by the default http client.
+	transportSet  bool               // Whether an option changed the transport, which must then be the one used.
+	userAgent     string             // The User-Agent header sent with every request; Go's default if empty.
+	headers       http.Header        // The headers passed to the http client when sending / receiving data from the endpoint.
+	tokenProvider TokenProvider      // Where the token sent in the Authorization header comes from.
+	skipAuthCheck bool               // Skip the Ping call on startup (useful when API is unreachable).
+	decodingMode  StrictDecodingMode // What to do when a response doesn't match the types in this package.
+
+	// state.
+	rateLimit *rateLimitState // The latest rate limit status reported by the API.
 
 	// misc.
 	logLevel slog.Level   // The log level of the default logger.
 	logger   *slog.Logger // The logger used in this client (custom or default).
-}
 
-// New creates and returns a new Client, initialized with the provided token.
-// The client itself is set up with tracing, logging, and HTTP configuration.
-// Additional options can be provided to modify its behavior, via the options
-// slice. The client is used for making requests and interacting with the Up
-// Bank API.
-func New(ctx context.Context, token string, options ...Option) (*Client, error) {
+	logRedaction LogRedaction // What is redacted from the logger's output.
 
-	// setup tracing.
-	tracerName := "up-go"
-	newCtx, span := otel.Tracer(tracerName).Start(ctx, "New")
-	defer span.End()
+	defaultLogger bool // Whether logger is the default logger, created from logLevel.
+}
 
-	// check args.
-	if token == "" {
-		return nil, ErrClientEmptyToken{}
-	}
+// New creates and returns a new Client, initialized with the provided token
+// (which may be empty if WithTokenProvider is given instead). The client
+// itself is set up with tracing, logging, and HTTP configuration. Additional
+// options can be provided to modify its behavior, via the options slice. The
+// client is used for making requests and interacting with the Up Bank API.
+func New(ctx context.Context, token string, options ...Option) (*Client, error) {
 
 	// clone http.DefaultTransport so we inherit all system-level settings
 	// (DNS resolver, TLS config, proxy env vars) and only override the
@@ -60,12 +66,14 @@ func New(ctx context.Context, token string, options ...Option) (*Client, error)
 	dt.MaxIdleConnsPerHost = 20
 	dt.IdleConnTimeout = 90 * time.Second
 	c := &Client{
-		tracerName: tracerName,
+		tracerName: "up-go",
 		httpClient: &http.Client{
 			Timeout:   30 * time.Second,
 			Transport: dt,
 		},
-		endpoint: "https://api.up.com.au/api/v1",
+		transport: dt,
+		endpoint:  "https://api.up.com.au/api/v1",
+		rateLimit: &rateLimitState{},
 	}
 
 	// overwrite client with any given options.
@@ -75,24 +83,27 @@ func New(ctx context.Context, token string, options ...Option) (*Client, error)
 		}
 	}
 
-	// determine if the default logger should be used.
-	if c.logger == nil {
-		c.logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: c.logLevel,
-			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-				if a.Key == slog.TimeKey {
-					a.Value = slog.StringValue(a.Value.Time().Format("2006-01-02 15:04:05"))
-				}
-				return a
-			},
-		}))
+	// the transport options only configure the default http client, so they
+	// can't be dropped silently when a different client is given.
+	if hc, ok := c.httpClient.(*http.Client); c.transportSet && (!ok || hc.Transport != c.transport) {
+		return nil, ErrClientFailedToSetOption{ErrClientTransportReplaced{}}
 	}
 
-	// setup headers.
-	headers := make(http.Header)
-	headers.Set("Authorization", "Bearer "+token)
-	headers.Set("Content-Type", "application/json")
-	c.headers = headers
+	// setup tracing.
+	newCtx, span := c.tracer().Start(ctx, "New")
+	defer span.End()
+
+	// check args.
+	if c.tokenProvider == nil {
+		if token == "" {
+			return nil, ErrClientEmptyToken{}
+		}
+		c.tokenProvider = NewStaticTokenProvider(token)
+	}
+
+	if err := c.setup(); err != nil {
+		return nil, err
+	}
 
 	// validate the token immediately by pinging the API.
 	// Skipped if WithSkipAuthCheck was used.
@@ -106,3 +117,72 @@ func New(ctx context.Context, token string, options ...Option) (*Client, error)
 	return c, nil
 }
 
+// setup finishes configuring the client once its options have been applied.
+func (c *Client) setup() error {
+
+	// setup metrics.
+	mp := c.meterProvider
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	metrics, err := newClientMetrics(mp, c.tracerName)
+	if err != nil {
+		return ErrClientFailedToSetupMetrics{err}
+	}
+	c.metrics = metrics
+
+	// determine if the default logger should be used.
+	// the default logger writes to slog.Default(), at the client's log level,
+	// so the client doesn't write to stdout unless the application does; pass
+	// slog.New(slog.DiscardHandler) to WithLogger to discard its logs instead.
+	if c.logger == nil {
+		c.defaultLogger = true
+		c.logger = slog.New(&levelHandler{level: c.logLevel})
+	}
+
+	// redact the logger's output; replacing any redaction already added by the
+	// client this one was derived from.
+	if h, ok := c.logger.Handler().(*redactingHandler); ok && h.client {
+		c.logger = slog.New(h.next)
+	}
+	if c.logRedaction != LogRedactionNone {
+		c.logger = slog.New(&redactingHandler{c.logger.Handler(), c.logRedaction, true})
+	}
+
+	// setup headers.
+	headers := make(http.Header)
+	headers.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		headers.Set("User-Agent", c.userAgent)
+	}
+	c.headers = headers
+	return nil
+}
+
+// With returns a copy of the client with the given options applied, for when
+// a job needs a variant of a client (eg. a different logger or timeout). The
+// copy shares the client's transport (and so its connection pool), token
+// provider and rate limit status, doesn't ping the API again, and is safe to
+// use concurrently with the client. Options that change the transport, such as
+// WithProxy, can't be used with With; create a new client with New instead.
+func (c *Client) With(options ...Option) (*Client, error) {
+	d := *c
+	d.transport = nil
+	if hc, ok := c.httpClient.(*http.Client); ok {
+		clone := *hc
+		d.httpClient = &clone
+	}
+	if d.defaultLogger {
+		d.logger = nil
+		d.defaultLogger = false
+	}
+	for _, o := range options {
+		if err := o(&d); err != nil {
+			return nil, ErrClientFailedToSetOption{err}
+		}
+	}
+	if err := d.setup(); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
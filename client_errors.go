@@ -29,3 +29,62 @@ type ErrClientFailedToSetOption struct {
 func (e ErrClientFailedToSetOption) Error() string {
 	return fmt.Sprintf("failed to set option in client: %v", e.err)
 }
+
+// ErrClientInvalidURL is returned when an option is given a URL that can't be
+// parsed, or isn't absolute.
+type ErrClientInvalidURL struct {
+	url string
+	err error
+}
+
+func (e ErrClientInvalidURL) Error() string {
+	return fmt.Sprintf("invalid url %q: %v", e.url, e.err)
+}
+
+// ErrClientTransportShared is returned when an option that changes the
+// transport is given to Client.With, whose clients share their transport.
+type ErrClientTransportShared struct {
+}
+
+func (e ErrClientTransportShared) Error() string {
+	return "transport options can't be used with Client.With, which shares the transport"
+}
+
+// ErrClientTransportReplaced is returned when an option that changes the
+// transport of the default http client is used with WithHttpClient, which
+// replaces that client.
+type ErrClientTransportReplaced struct {
+}
+
+func (e ErrClientTransportReplaced) Error() string {
+	return "transport options can't be used with WithHttpClient; configure the given client's transport instead"
+}
+
+// ErrClientNotHttpClient is returned when an option that changes the
+// *http.Client is used after WithHttpClient was given a different client type.
+type ErrClientNotHttpClient struct {
+}
+
+func (e ErrClientNotHttpClient) Error() string {
+	return "the client's http client isn't a *http.Client"
+}
+
+// ErrClientFailedToSetupMetrics is returned when the instruments used to
+// record metrics can't be created from the meter provider.
+type ErrClientFailedToSetupMetrics struct {
+	err error
+}
+
+func (e ErrClientFailedToSetupMetrics) Error() string {
+	return fmt.Sprintf("failed to setup metrics: %v", e.err)
+}
+
+// ErrClientInvalidDecodingMode is returned when WithStrictDecoding is given a
+// mode that isn't defined in this package.
+type ErrClientInvalidDecodingMode struct {
+	mode StrictDecodingMode
+}
+
+func (e ErrClientInvalidDecodingMode) Error() string {
+	return fmt.Sprintf("invalid strict decoding mode %q", e.mode)
+}
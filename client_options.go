@@ -1,11 +1,25 @@
 package up
 
-import "log/slog"
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
 
 // Option configures a departure client.
 type Option func(*Client) error
 
-// WithLogLevel sets the log level for the default logger.
+// WithLogLevel sets the minimum log level for the default logger, which
+// writes to slog.Default(); records must be at or above both this level and
+// the level of slog.Default() to be logged.
 func WithLogLevel(level slog.Level) Option {
 	return func(c *Client) error {
 		c.logLevel = level
@@ -21,7 +35,20 @@ func WithLogger(logger *slog.Logger) Option {
 	}
 }
 
+// WithLogRedaction redacts the given details from everything the client logs,
+// including the response bodies logged at Debug level. Defaults to
+// LogRedactionNone.
+func WithLogRedaction(redaction LogRedaction) Option {
+	return func(c *Client) error {
+		c.logRedaction = redaction
+		return nil
+	}
+}
+
 // WithHttpClient overwrites the default httpClient used for API communication.
+// The transport options (WithProxy, WithRootCAs and WithClientCertificate)
+// configure the default httpClient, so New returns an error if they're used
+// with this; configure the given client's transport instead.
 func WithHttpClient(httpClient iHttpClient) Option {
 	return func(c *Client) error {
 		c.httpClient = httpClient
@@ -29,6 +56,132 @@ func WithHttpClient(httpClient iHttpClient) Option {
 	}
 }
 
+// parseAbsoluteURL parses the given raw URL, which must be absolute.
+func parseAbsoluteURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, ErrClientInvalidURL{raw, err}
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, ErrClientInvalidURL{raw, errors.New("must be absolute")}
+	}
+	return u, nil
+}
+
+// WithEndpoint overwrites the endpoint the client sends requests to (eg. a
+// local stand-in for the API, or a recording proxy). Defaults to
+// https://api.up.com.au/api/v1.
+func WithEndpoint(endpoint string) Option {
+	return func(c *Client) error {
+		if _, err := parseAbsoluteURL(endpoint); err != nil {
+			return err
+		}
+		c.endpoint = strings.TrimSuffix(endpoint, "/")
+		return nil
+	}
+}
+
+// WithProxy sends requests through the proxy at the given URL, instead of
+// any proxy set in the environment (eg. HTTPS_PROXY).
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) error {
+		u, err := parseAbsoluteURL(proxyURL)
+		if err != nil {
+			return err
+		}
+		if c.transport == nil {
+			return ErrClientTransportShared{}
+		}
+		c.transport.Proxy = http.ProxyURL(u)
+		c.transportSet = true
+		return nil
+	}
+}
+
+// tlsConfig returns the TLS config of the client's transport, creating it if
+// it isn't set, for an option to change.
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	if c.transport == nil {
+		return nil, ErrClientTransportShared{}
+	}
+	if c.transport.TLSClientConfig == nil {
+		c.transport.TLSClientConfig = &tls.Config{}
+	}
+	c.transportSet = true
+	return c.transport.TLSClientConfig, nil
+}
+
+// WithRootCAs overwrites the certificate authorities used to verify the API's
+// certificate (eg. for corporate networks that intercept TLS). Defaults to the
+// system's certificate pool.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) error {
+		cfg, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		cfg.RootCAs = pool
+		return nil
+	}
+}
+
+// WithClientCertificate presents the given certificate when connecting (eg.
+// to a proxy that requires mutual TLS); use tls.LoadX509KeyPair to load one
+// from files.
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(c *Client) error {
+		cfg, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+		cfg.Certificates = append(cfg.Certificates, cert)
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for each request sent to the API, including
+// reading the response. Defaults to 30s. It must be given after WithHttpClient,
+// if both are used; the client given to WithHttpClient is copied, not changed.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		hc, ok := c.httpClient.(*http.Client)
+		if !ok {
+			return ErrClientNotHttpClient{}
+		}
+		cp := *hc
+		cp.Timeout = timeout
+		c.httpClient = &cp
+		return nil
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithTracerProvider sets the provider of the tracer the client starts spans
+// with. Defaults to the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Client) error {
+		c.tracerProvider = provider
+		return nil
+	}
+}
+
+// WithMeterProvider sets the provider of the meter the client records metrics
+// with (eg. request counts and latencies). Defaults to the global meter
+// provider, which discards metrics unless otel.SetMeterProvider is called.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *Client) error {
+		c.meterProvider = provider
+		return nil
+	}
+}
+
 // WithSkipAuthCheck disables the Ping call that normally happens at startup.
 // Use this when the Up API may be unreachable at init time (e.g. restrictive
 // corporate networks). Tools will still fail gracefully if unreachable.
@@ -38,3 +191,32 @@ func WithSkipAuthCheck() Option {
 		return nil
 	}
 }
+
+// WithStrictDecoding sets what the client does when a response from the API
+// doesn't match the types in this package - fields Up has added or renamed
+// since this package was released (which would otherwise be silently dropped),
+// unknown enum values, and Money whose value and value in base units disagree.
+// Defaults to StrictDecodingModeIgnore. Unknown enum values are only ever
+// warned about, since Up adds them without notice.
+func WithStrictDecoding(mode StrictDecodingMode) Option {
+	return func(c *Client) error {
+		switch mode {
+		case StrictDecodingModeIgnore, StrictDecodingModeWarn, StrictDecodingModeError:
+		default:
+			return ErrClientInvalidDecodingMode{mode}
+		}
+		c.decodingMode = mode
+		return nil
+	}
+}
+
+// WithTokenProvider sets where the client gets its token from, instead of the
+// token given to New (which may then be empty). The token is fetched before
+// every request, and refreshed once if the API rejects it, so a long-running
+// client survives the token being rotated.
+func WithTokenProvider(provider TokenProvider) Option {
+	return func(c *Client) error {
+		c.tokenProvider = provider
+		return nil
+	}
+}
@@ -3,11 +3,14 @@ package up
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"io"
 	"log/slog"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -105,6 +108,12 @@ func Test_New(t *testing.T) {
 				logger:     logger,
 			},
 		},
+		"with invalid strict decoding mode": {
+			token:   "xxxx",
+			options: []Option{WithStrictDecoding("STRICT")},
+			want:    &Client{},
+			err:     `invalid strict decoding mode "STRICT"`,
+		},
 		"with http client": {
 			token:   "xxxx",
 			options: []Option{WithHttpClient(httpClient)},
@@ -116,12 +125,6 @@ func Test_New(t *testing.T) {
 	}
 	for name, tt := range tests {
 
-		// setup headers.
-		headers := make(http.Header)
-		if tt.token != "" {
-			headers.Add("Authorization", "Bearer "+tt.token)
-		}
-
 		// inject a mock that returns a successful ping so New() doesn't hit
 		// the real API during tests. We prepend it so test-specific options
 		// (like WithHttpClient) can override it afterwards.
@@ -153,11 +156,12 @@ func Test_New(t *testing.T) {
 				t.Errorf("New() returned an error; error=%v", err)
 				return
 			}
+			token, _ := got.tokenProvider.Token(context.Background())
 			switch {
 			case
 				got.logLevel != tt.want.logLevel,
 				(got.logger != slog.Default() && tt.want.logger != slog.Default()) && got.logger != tt.want.logger,
-				got.headers.Get("Authorization") != headers.Get("Authorization"):
+				token != tt.token:
 				t.Errorf(
 					"New() returned unexpected configuration; want=%+v, got=%+v\n",
 					tt.want,
@@ -168,3 +172,192 @@ func Test_New(t *testing.T) {
 		})
 	}
 }
+
+func Test_New_transportOptions(t *testing.T) {
+
+	// setup a stand-in for the API, with its own certificate.
+	var userAgent string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write(pingTestdata.content)
+	}))
+	defer srv.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	tests := map[string]struct {
+		options []Option
+		err     string
+	}{
+		"endpoint with root CAs": {
+			options: []Option{
+				WithEndpoint(srv.URL + "/"),
+				WithRootCAs(pool),
+				WithUserAgent("up-go-test"),
+			},
+		},
+		"endpoint without root CAs": {
+			options: []Option{WithEndpoint(srv.URL)},
+			err:     "certificate",
+		},
+		"invalid endpoint": {
+			options: []Option{WithEndpoint("api.up.com.au")},
+			err:     "must be absolute",
+		},
+		"invalid proxy": {
+			options: []Option{WithProxy("://")},
+			err:     "invalid url",
+		},
+		"proxy before http client": {
+			options: []Option{WithProxy("http://proxy.example.com:3128"), WithHttpClient(&http.Client{})},
+			err:     "can't be used with WithHttpClient",
+		},
+		"root CAs after http client": {
+			options: []Option{WithHttpClient(&http.Client{}), WithRootCAs(pool)},
+			err:     "can't be used with WithHttpClient",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			userAgent = ""
+			_, err := New(context.Background(), "xxxx", tt.options...)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("New() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() returned an error; error=%v", err)
+			}
+			if userAgent != "up-go-test" {
+				t.Errorf("New() sent an unexpected User-Agent; want=up-go-test, got=%v", userAgent)
+			}
+		})
+	}
+
+	// the proxy and client certificate build on the default transport.
+	cert := srv.TLS.Certificates[0]
+	c, err := New(context.Background(), "xxxx",
+		WithProxy("http://proxy.example.com:3128"),
+		WithClientCertificate(cert),
+		WithSkipAuthCheck(),
+	)
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+	proxy, err := c.transport.Proxy(httptest.NewRequest(http.MethodGet, "https://api.up.com.au", nil))
+	if err != nil || proxy.String() != "http://proxy.example.com:3128" {
+		t.Errorf("New() set an unexpected proxy; got=%v, err=%v", proxy, err)
+	}
+	if got := c.transport.TLSClientConfig.Certificates; len(got) != 1 {
+		t.Errorf("New() set unexpected client certificates; got=%v", len(got))
+	}
+	if c.transport.MaxIdleConnsPerHost != 20 {
+		t.Errorf("New() replaced the default transport; got=%+v", c.transport)
+	}
+}
+
+func Test_WithTimeout(t *testing.T) {
+	hc := &http.Client{Transport: &mockRoundTripper{}, Timeout: time.Minute}
+	c, err := New(context.Background(), "xxxx",
+		WithHttpClient(hc),
+		WithTimeout(time.Second),
+		WithSkipAuthCheck(),
+	)
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+
+	// the given client is copied, so it's left unchanged.
+	got := c.httpClient.(*http.Client)
+	switch {
+	case
+		got == hc,
+		got.Timeout != time.Second,
+		got.Transport != hc.Transport,
+		hc.Timeout != time.Minute:
+		t.Errorf("WithTimeout() returned unexpected configuration;\ngiven=%+v\ngot=%+v\n", hc, got)
+	}
+}
+
+func Test_Client_With(t *testing.T) {
+	var calls atomic.Int32
+	var userAgents []string
+	mock := &mockRoundTripper{
+		MockFunc: func(req *http.Request) *http.Response {
+			calls.Add(1)
+			userAgents = append(userAgents, req.Header.Get("User-Agent"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer(pingTestdata.content)),
+				Header:     make(http.Header),
+			}
+		},
+	}
+	parent, err := New(context.Background(), "xxxx",
+		WithHttpClient(&http.Client{Transport: mock}),
+		WithTimeout(30*time.Second),
+	)
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+
+	tests := map[string]struct {
+		options []Option
+		err     string
+	}{
+		"timeout and user agent": {
+			options: []Option{WithTimeout(time.Second), WithUserAgent("up-go-test")},
+		},
+		"log level": {
+			options: []Option{WithLogLevel(slog.LevelDebug)},
+		},
+		"transport option": {
+			options: []Option{WithProxy("http://proxy.example.com:3128")},
+			err:     ErrClientTransportShared{}.Error(),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls.Store(0)
+			userAgents = nil
+			got, err := parent.With(tt.options...)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("With() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("With() returned an error; error=%v", err)
+			}
+			if calls.Load() != 0 {
+				t.Errorf("With() pinged the API; calls=%v", calls.Load())
+			}
+
+			// the derived client shares the transport and token provider,
+			// without changing the parent.
+			gotHC, parentHC := got.httpClient.(*http.Client), parent.httpClient.(*http.Client)
+			switch {
+			case
+				gotHC == parentHC,
+				gotHC.Transport != parentHC.Transport,
+				got.tokenProvider != parent.tokenProvider,
+				got.rateLimit != parent.rateLimit,
+				parentHC.Timeout != 30*time.Second,
+				parent.headers.Get("User-Agent") != "":
+				t.Errorf("With() returned unexpected configuration;\nparent=%+v\ngot=%+v\n", parent, got)
+			}
+			if _, err := got.Ping(context.Background()); err != nil {
+				t.Errorf("Ping() returned an error; error=%v", err)
+			}
+			if _, err := parent.Ping(context.Background()); err != nil {
+				t.Errorf("Ping() returned an error; error=%v", err)
+			}
+			if userAgents[1] != "" {
+				t.Errorf("With() changed the parent's User-Agent; got=%v", userAgents[1])
+			}
+		})
+	}
+}
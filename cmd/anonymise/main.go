@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jmpa-io/up-go"
+)
+
+func main() {
+
+	// parse flags.
+	seed := flag.Uint64("seed", 0, "the seed to anonymise with; files anonymised with the same seed keep their relationships")
+	out := flag.String("out", "testdata", "the directory to write the anonymised files to, or - for stdout")
+	dateShift := flag.Duration("date-shift", 30*24*time.Hour, "the most dates are shifted by, either way")
+	amountScale := flag.Float64("amount-scale", 0.2, "the most amounts are scaled by, either way (eg. 0.2 for 80% to 120%)")
+	endpoint := flag.String("endpoint", "https://api.up.com.au/api/v1", "the endpoint links are rewritten to use")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: anonymise [flags] file...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// setup anonymiser.
+	a := up.NewAnonymiser(
+		up.AnonymiserOptionSeed(*seed),
+		up.AnonymiserOptionDateShift(*dateShift),
+		up.AnonymiserOptionAmountScale(*amountScale),
+		up.AnonymiserOptionEndpoint(*endpoint),
+	)
+
+	// anonymise files; files with the same name would overwrite each other, so
+	// are refused before anything is written.
+	if *out != "-" {
+		inputs := make(map[string]string, flag.NArg())
+		for _, path := range flag.Args() {
+			name := filepath.Base(path)
+			if prev, ok := inputs[name]; ok {
+				fmt.Printf("refusing to write both %s and %s to %s; anonymise them to different -out directories\n", prev, path, filepath.Join(*out, name))
+				os.Exit(1)
+			}
+			inputs[name] = path
+		}
+		if err := os.MkdirAll(*out, 0o755); err != nil {
+			fmt.Printf("failed to create output directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	for _, path := range flag.Args() {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("failed to read %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		anonymised, err := a.Anonymise(b)
+		if err != nil {
+			fmt.Printf("failed to anonymise %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		if *out == "-" {
+			os.Stdout.Write(anonymised)
+			continue
+		}
+		dest := filepath.Join(*out, filepath.Base(path))
+		if sameFile(path, dest) {
+			fmt.Printf("refusing to overwrite %s with its anonymised copy; use -out to write it elsewhere\n", path)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(dest, anonymised, 0o644); err != nil {
+			fmt.Printf("failed to write %s: %v\n", dest, err)
+			os.Exit(1)
+		}
+		fmt.Printf("anonymised %s to %s\n", path, dest)
+	}
+}
+
+// sameFile reports whether the given paths are the same file.
+func sameFile(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(ai, bi)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/jmpa-io/up-go"
+	"github.com/jmpa-io/up-go/uptest"
+)
+
+func main() {
+
+	// parse flags.
+	seed := flag.Uint64("seed", 1, "the seed to generate data with; the same seed always generates the same data")
+	months := flag.Int("months", 3, "how many months of transactions to generate")
+	purchases := flag.Int("purchases", 60, "how many card purchases to generate each month")
+	out := flag.String("out", "generated", "the directory to write the generated pages to")
+	pageSize := flag.Int("page-size", 20, "how many resources to write to each page")
+	endpoint := flag.String("endpoint", "https://api.up.com.au/api/v1", "the endpoint used in the links between pages")
+	serve := flag.Bool("serve", false, "serve the generated data from a fake API, until interrupted")
+	flag.Parse()
+
+	// generate data.
+	d := uptest.Generate(*seed,
+		uptest.GenerateOptionMonths(*months),
+		uptest.GenerateOptionPurchasesPerMonth(*purchases),
+	)
+
+	// write pages; transactions are newest first, as the API returns them.
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		fmt.Printf("failed to create output directory: %v\n", err)
+		os.Exit(1)
+	}
+	transactions := slices.Clone(d.Transactions)
+	slices.Reverse(transactions)
+	for _, err := range []error{
+		writePages(*out, *endpoint, "accounts", *pageSize, d.Accounts, func(a up.AccountDataWrapper) string { return a.ID }),
+		writePages(*out, *endpoint, "transactions", *pageSize, transactions, func(t up.TransactionDataWrapper) string { return t.ID }),
+		writeFile(filepath.Join(*out, "categories.json"), up.CategoryPaginationWrapper{Data: d.Categories}),
+	} {
+		if err != nil {
+			fmt.Printf("failed to write pages: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("wrote %v accounts, %v transactions and %v categories to %s\n",
+		len(d.Accounts), len(d.Transactions), len(d.Categories), *out)
+
+	// serve data.
+	if !*serve {
+		return
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	s := uptest.NewServer(uptest.WithDataset(d))
+	defer s.Close()
+	fmt.Printf("serving on %s with token %q; interrupt to stop\n", s.Endpoint(), uptest.DefaultToken)
+	<-ctx.Done()
+}
+
+// writePages writes the given items as pages named after the given resource
+// (eg. transactions-1.json), linked as the API links them.
+func writePages[T any](dir, endpoint, resource string, size int, items []T, id func(T) string) error {
+	for page, start := 1, 0; start < len(items) || page == 1; page, start = page+1, start+size {
+		end := min(start+size, len(items))
+		wrapper := up.WrapperSlice[T]{Data: items[start:end]}
+		if end < len(items) {
+			wrapper.Links.Next = endpoint + "/" + resource + "?" + url.Values{
+				"page[after]": {id(items[end-1])},
+				"page[size]":  {strconv.Itoa(size)},
+			}.Encode()
+		}
+		if err := writeFile(filepath.Join(dir, fmt.Sprintf("%s-%d.json", resource, page)), wrapper); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile writes the given value to the given file as indented JSON.
+func writeFile(path string, v interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
@@ -8,8 +8,10 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
@@ -85,6 +87,29 @@ func main() {
 	}()
 	otel.SetTracerProvider(tp)
 
+	// setup metric exporter.
+	mexp, err := newMetricExporter()
+	if err != nil {
+		h.logger.Error("failed to setup metric exporter",
+			"type", "grpc",
+			"error", err,
+		)
+		os.Exit(1)
+	}
+
+	// setup meter provider.
+	mp := metric.NewMeterProvider(
+		metric.WithReader(metric.NewPeriodicReader(mexp)),
+		metric.WithResource(newResource(h.name, h.version, h.environment)),
+	)
+	defer func() {
+		if err := mp.Shutdown(context.TODO()); err != nil {
+			h.logger.Error("failed to shutdown meter provider", "error", err)
+			os.Exit(1)
+		}
+	}()
+	otel.SetMeterProvider(mp)
+
 	// ---
 
 	// setup span.
@@ -95,7 +120,10 @@ func main() {
 	token := os.Getenv("UP_TOKEN")
 
 	// setup client.
-	h.upsvc, err = up.New(ctx, token, up.WithLogger(h.logger))
+	h.upsvc, err = up.New(ctx, token,
+		up.WithLogger(h.logger),
+		up.WithMeterProvider(mp),
+	)
 	if err != nil {
 		h.logger.Error("failed to setup client", "client", "up", "error", err)
 		os.Exit(1)
@@ -131,6 +159,17 @@ func newExporter() (*otlptrace.Exporter, error) {
 	)
 }
 
+// newMetricExporter returns a started grpc metric exporter.
+//
+// NOTE: set 'OTEL_EXPORTER_OTLP_METRICS_ENDPOINT' to change the endpoint from
+// https://localhost:4317 to another endpoint.
+func newMetricExporter() (*otlpmetricgrpc.Exporter, error) {
+	return otlpmetricgrpc.New(
+		context.TODO(),
+		otlpmetricgrpc.WithInsecure(),
+	)
+}
+
 // getEnv retrieves an environment variable value with a default fallback.
 func getEnv(key, fallback string) string {
 	value := os.Getenv(key)
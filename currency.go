@@ -0,0 +1,57 @@
+package up
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// iso4217 is the table of currencies, in CSV form, with the header
+// "code,exponent,symbol,name".
+//
+//go:embed iso4217.csv
+var iso4217 []byte
+
+// Currency holds the ISO 4217 details of a currency.
+type Currency struct {
+	Code     string // The ISO 4217 alphabetic code (eg. "AUD").
+	Exponent int    // The number of minor units (eg. 2 for cents).
+	Symbol   string // The local currency symbol (eg. "$"); may be ambiguous across currencies.
+	Name     string // The English name of the currency.
+}
+
+// currencies returns the embedded ISO 4217 table, by currency code.
+var currencies = sync.OnceValue(func() map[string]Currency {
+	records, err := csv.NewReader(bytes.NewReader(iso4217)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read embedded ISO 4217 table: %v", err))
+	}
+	m := make(map[string]Currency, len(records))
+	for _, r := range records[1:] {
+		exponent, err := strconv.Atoi(r[1])
+		if err != nil {
+			panic(fmt.Sprintf("invalid exponent for %s in embedded ISO 4217 table: %v", r[0], err))
+		}
+		m[r[0]] = Currency{Code: r[0], Exponent: exponent, Symbol: r[2], Name: r[3]}
+	}
+	return m
+})
+
+// LookupCurrency returns the ISO 4217 details for the given currency code,
+// and whether the currency is known.
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies()[code]
+	return c, ok
+}
+
+// currencyExponent returns the number of minor units in the given currency,
+// assuming 2 for currencies that aren't known.
+func currencyExponent(currencyCode string) int {
+	if c, ok := LookupCurrency(currencyCode); ok {
+		return c.Exponent
+	}
+	return 2
+}
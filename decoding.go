@@ -0,0 +1,236 @@
+package up
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// StrictDecodingMode defines what the client does when a response from the
+// API doesn't match the types in this package, such as when Up adds a new
+// enum value.
+type StrictDecodingMode string
+
+const (
+	StrictDecodingModeIgnore StrictDecodingMode = "IGNORE" // Decode as much as possible, silently (the default).
+	StrictDecodingModeWarn   StrictDecodingMode = "WARN"   // Decode as much as possible, logging a warning and adding a span event for each issue.
+	StrictDecodingModeError  StrictDecodingMode = "ERROR"  // Return an ErrStrictDecoding listing the issues, warning as above for unknown enum values.
+)
+
+// DecodeIssueKind defines the kinds of issues found by strict decoding.
+type DecodeIssueKind string
+
+const (
+	DecodeIssueKindUnknownEnumValue DecodeIssueKind = "UNKNOWN_ENUM_VALUE" // An enum value not defined in this package.
+	DecodeIssueKindUnknownField     DecodeIssueKind = "UNKNOWN_FIELD"      // A field with no matching struct field, so it was dropped.
+	DecodeIssueKindInvalidMoney     DecodeIssueKind = "INVALID_MONEY"      // Money whose value and value in base units disagree.
+)
+
+// DecodeIssue describes a part of a response from the API that doesn't match
+// the types in this package.
+type DecodeIssue struct {
+	Kind  DecodeIssueKind // What the issue is.
+	Path  string          // Where in the response the issue is (eg. "data[0].attributes.status").
+	Value string          // The offending value.
+}
+
+func (i DecodeIssue) String() string {
+	return fmt.Sprintf("%s at %s: %s", i.Kind, i.Path, i.Value)
+}
+
+// knowable is implemented by the enum types in this package, which can tell
+// whether their value is one documented by Up.
+type knowable interface {
+	IsKnown() bool
+}
+
+var (
+	knowableType    = reflect.TypeFor[knowable]()
+	moneyType       = reflect.TypeFor[Money]()
+	unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// DiffSchema decodes the given payload (eg. a live response from the API, or
+// a file in testdata) into v, a pointer to one of the types in this package,
+// and returns every issue found with it - fields the payload has that v's
+// type doesn't, unknown enum values, and inconsistent Money. It's useful for
+// checking whether the types in this package, or any saved payloads, are out
+// of date.
+func DiffSchema(payload []byte, v interface{}) ([]DecodeIssue, error) {
+	if err := json.Unmarshal(payload, v); err != nil {
+		return nil, ErrFailedUnmarshal{err}
+	}
+	var raw interface{}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, ErrFailedUnmarshal{err}
+	}
+	issues := findUnknownFields(raw, reflect.TypeOf(v), "")
+	return append(issues, findDecodeIssues(v)...), nil
+}
+
+// findUnknownFields compares the given raw JSON against the given type, as
+// encoding/json would when decoding into it, returning an issue for each field
+// that has nowhere to go.
+func findUnknownFields(raw interface{}, t reflect.Type, path string) (issues []DecodeIssue) {
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch raw := raw.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			keys := make([]string, 0, len(raw))
+			for k := range raw {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				f, ok := fields[strings.ToLower(k)]
+				if !ok {
+					issues = append(issues, DecodeIssue{
+						Kind:  DecodeIssueKindUnknownField,
+						Path:  joinPath(path, k),
+						Value: truncate(fmt.Sprint(raw[k]), 64),
+					})
+					continue
+				}
+				issues = append(issues, findUnknownFields(raw[k], f, joinPath(path, k))...)
+			}
+		case reflect.Map:
+			for k, v := range raw {
+				issues = append(issues, findUnknownFields(v, t.Elem(), joinPath(path, k))...)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, v := range raw {
+				issues = append(issues, findUnknownFields(v, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return issues
+}
+
+// jsonFields returns the types of the fields of the given struct type, by
+// their lower-cased JSON name, flattening embedded structs as encoding/json
+// does.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+			continue
+		case name == "" && f.Anonymous && f.Type.Kind() == reflect.Struct:
+			for n, ft := range jsonFields(f.Type) {
+				if _, ok := fields[n]; !ok {
+					fields[n] = ft
+				}
+			}
+			continue
+		case !f.IsExported():
+			continue
+		case name == "":
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+// truncate shortens s to at most n bytes, for including in issues.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
+
+// findDecodeIssues walks the given decoded value, returning any unknown enum
+// values or invalid Money found in it.
+func findDecodeIssues(v interface{}) (issues []DecodeIssue) {
+	walkDecoded(reflect.ValueOf(v), "", func(v reflect.Value, path string) {
+		if v.Type() == moneyType {
+			m := v.Interface().(Money)
+			if err := m.Validate(); err != nil {
+				issues = append(issues, DecodeIssue{
+					Kind:  DecodeIssueKindInvalidMoney,
+					Path:  path,
+					Value: fmt.Sprintf("%s %s (%d base units)", m.CurrencyCode, m.Value, m.ValueInBaseUnits),
+				})
+			}
+		}
+		if v.Kind() == reflect.String && v.String() != "" && v.Type().Implements(knowableType) {
+			if !v.Interface().(knowable).IsKnown() {
+				issues = append(issues, DecodeIssue{
+					Kind:  DecodeIssueKindUnknownEnumValue,
+					Path:  path,
+					Value: fmt.Sprintf("%s(%q)", v.Type().Name(), v.String()),
+				})
+			}
+		}
+	})
+	return issues
+}
+
+// walkDecoded calls fn for v and every value nested within it, along with its
+// path in JSON terms.
+func walkDecoded(v reflect.Value, path string, fn func(v reflect.Value, path string)) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	fn(v, path)
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			switch {
+			case name == "-":
+				continue
+			case name == "" && f.Anonymous:
+				walkDecoded(v.Field(i), path, fn)
+				continue
+			case name == "":
+				name = f.Name
+			}
+			walkDecoded(v.Field(i), joinPath(path, name), fn)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkDecoded(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fn)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			walkDecoded(iter.Value(), joinPath(path, fmt.Sprint(iter.Key())), fn)
+		}
+	}
+}
+
+// joinPath appends the given name to a JSON path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,25 @@
+package up
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrStrictDecoding is returned when strict decoding is set to
+// StrictDecodingModeError and a response from the API doesn't match the types
+// in this package.
+type ErrStrictDecoding struct {
+	Issues []DecodeIssue
+}
+
+func (e ErrStrictDecoding) Error() string {
+	var issues []string
+	for _, i := range e.Issues {
+		issues = append(issues, i.String())
+	}
+	return fmt.Sprintf(
+		"response doesn't match schema; count=%v, issues=%s",
+		len(issues),
+		strings.Join(issues, ";"),
+	)
+}
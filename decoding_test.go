@@ -0,0 +1,204 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_findDecodeIssues(t *testing.T) {
+	tests := map[string]struct {
+		v    interface{}
+		want []DecodeIssue
+	}{
+		"known values": {
+			v: &TransactionsPaginationWrapper{Data: []TransactionDataWrapper{
+				{Attributes: TransactionResource{
+					Status:          TransactionStatusSettled,
+					TransactionType: TransactionTypeRoundUp,
+				}},
+			}},
+		},
+		"empty values are ignored": {
+			v: &TransactionsPaginationWrapper{Data: []TransactionDataWrapper{{}}},
+		},
+		"unknown values": {
+			v: &TransactionsPaginationWrapper{Data: []TransactionDataWrapper{
+				{},
+				{Attributes: TransactionResource{
+					Status:          "PENDING",
+					TransactionType: "Crypto Purchase",
+					CardPurchaseMethod: TransactionResourceCardPurchaseMethod{
+						Method: "TELEPATHY",
+					},
+				}},
+			}},
+			want: []DecodeIssue{
+				{DecodeIssueKindUnknownEnumValue, "data[1].attributes.status", `TransactionStatus("PENDING")`},
+				{DecodeIssueKindUnknownEnumValue, "data[1].attributes.cardPurchaseMethod.method", `TransactionCardPurchaseMethod("TELEPATHY")`},
+				{DecodeIssueKindUnknownEnumValue, "data[1].attributes.transactionType", `TransactionType("Crypto Purchase")`},
+			},
+		},
+		"unknown account values": {
+			v: &AccountsPaginationWrapper{Data: []AccountDataWrapper{
+				{Attributes: AccountResource{AccountType: "CREDIT", OwnershipType: AccountOwnershipTypeJoint}},
+			}},
+			want: []DecodeIssue{
+				{DecodeIssueKindUnknownEnumValue, "data[0].attributes.accountType", `AccountType("CREDIT")`},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := findDecodeIssues(tt.v)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findDecodeIssues() returned unexpected issues;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_WithStrictDecoding(t *testing.T) {
+	unknownStatus := bytes.Replace(transactionTestdata.content, []byte(`"SETTLED"`), []byte(`"PENDING"`), 1)
+	unknownField := bytes.Replace(transactionTestdata.content, []byte(`"status"`), []byte(`"pendingReason": "PENDING", "status"`), 1)
+	tests := map[string]struct {
+		mode StrictDecodingMode
+		body []byte
+		err  bool
+	}{
+		"ignore unknown values": {
+			mode: StrictDecodingModeIgnore,
+			body: unknownStatus,
+		},
+		"warn on unknown values": {
+			mode: StrictDecodingModeWarn,
+			body: unknownStatus,
+		},
+		"warn on unknown values when strict": {
+			mode: StrictDecodingModeError,
+			body: unknownStatus,
+		},
+		"error on unknown fields": {
+			mode: StrictDecodingModeError,
+			body: unknownField,
+			err:  true,
+		},
+		"no unknown values": {
+			mode: StrictDecodingModeError,
+			body: transactionTestdata.content,
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		c := newTestClient(t, &mockRoundTripper{
+			MockFunc: func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(tt.body)),
+					Header:     make(http.Header),
+				}
+			},
+		})
+		c.decodingMode = tt.mode
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			_, err := c.GetTransaction(ctx, "1")
+			var e ErrStrictDecoding
+			if tt.err {
+				if !errors.As(err, &e) || !strings.Contains(err.Error(), "PENDING") {
+					t.Errorf("GetTransaction() returned an unexpected error;\nerror=%v\n", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("GetTransaction() returned an error;\nerror=%v\n", err)
+			}
+		})
+	}
+}
+
+func Test_DiffSchema(t *testing.T) {
+	tests := map[string]struct {
+		payload string
+		v       interface{}
+		want    []DecodeIssue
+	}{
+		"matching payload": {
+			payload: `{"meta":{"id":"1","statusEmoji":"⚡️"}}`,
+			v:       &Ping{},
+		},
+		"unknown fields": {
+			payload: `{"meta":{"id":"1","statusEmoji":"⚡️","mood":"happy"},"extra":[1]}`,
+			v:       &Ping{},
+			want: []DecodeIssue{
+				{DecodeIssueKindUnknownField, "extra", "[1]"},
+				{DecodeIssueKindUnknownField, "meta.mood", "happy"},
+			},
+		},
+		"invalid money": {
+			payload: `{"data":{"attributes":{"balance":{"currencyCode":"AUD","value":"1.00","valueInBaseUnits":1}}}}`,
+			v:       &Wrapper[AccountDataWrapper]{},
+			want: []DecodeIssue{
+				{DecodeIssueKindInvalidMoney, "data.attributes.balance", "AUD 1.00 (1 base units)"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := DiffSchema([]byte(tt.payload), tt.v)
+			if err != nil {
+				t.Errorf("DiffSchema() returned an error;\nerror=%v\n", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffSchema() returned unexpected issues;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+// Test_testdataSchema checks that the payloads in testdata don't have any
+// fields the types in this package are missing, so they're kept up to date.
+func Test_testdataSchema(t *testing.T) {
+	tests := map[string]func() interface{}{
+		"account":        func() interface{} { return &Wrapper[AccountDataWrapper]{} },
+		"accounts-1":     func() interface{} { return &AccountsPaginationWrapper{} },
+		"accounts-2":     func() interface{} { return &AccountsPaginationWrapper{} },
+		"accounts-3":     func() interface{} { return &AccountsPaginationWrapper{} },
+		"categories":     func() interface{} { return &CategoryPaginationWrapper{} },
+		"category":       func() interface{} { return &Wrapper[CategoryData]{} },
+		"ping":           func() interface{} { return &Ping{} },
+		"tags-1":         func() interface{} { return &TagsPaginationWrapper{} },
+		"tags-2":         func() interface{} { return &TagsPaginationWrapper{} },
+		"tags-3":         func() interface{} { return &TagsPaginationWrapper{} },
+		"transaction":    func() interface{} { return &Wrapper[TransactionDataWrapper]{} },
+		"transactions-1": func() interface{} { return &TransactionsPaginationWrapper{} },
+		"transactions-2": func() interface{} { return &TransactionsPaginationWrapper{} },
+		"transactions-3": func() interface{} { return &TransactionsPaginationWrapper{} },
+		"unauthorized":   func() interface{} { return &apiErrorResponse{} },
+	}
+	for name, v := range tests {
+		t.Run(name, func(t *testing.T) {
+			issues, err := DiffSchema(newTestdata(name).content, v())
+			if err != nil {
+				t.Errorf("DiffSchema() returned an error;\nerror=%v\n", err)
+				return
+			}
+			for _, i := range issues {
+				if i.Kind == DecodeIssueKindUnknownField {
+					t.Errorf("testdata/%s.json has a field with no matching type; %v", name, i)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package up
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
 
 // ErrFailedMarshal is returned when an error is returned from json.Marshal.
 type ErrFailedMarshal struct {
@@ -19,3 +23,34 @@ type ErrFailedUnmarshal struct {
 func (e ErrFailedUnmarshal) Error() string {
 	return fmt.Sprintf("failed to unmarshal data: %v", e.err)
 }
+
+// FailureReason classifies why a request for a single item failed, when
+// reporting failures from calls that operate on many items at once.
+type FailureReason string
+
+const (
+	FailureReasonNotFound  FailureReason = "NOT_FOUND" // The item doesn't exist.
+	FailureReasonTransient FailureReason = "TRANSIENT" // The request may succeed if retried (eg. network errors, 429s, 5xxs).
+	FailureReasonPermanent FailureReason = "PERMANENT" // The request was rejected and won't succeed if retried.
+	FailureReasonPartial   FailureReason = "PARTIAL"   // The item was only partly changed, and should be resumed.
+)
+
+// failureReasonFor classifies the given error returned from the sender.
+func failureReasonFor(err error) FailureReason {
+	var (
+		sre ErrSenderFailedSendRequest
+		ire ErrSenderInvalidResponse
+	)
+	switch {
+	case errors.As(err, &sre):
+		return FailureReasonTransient
+	case !errors.As(err, &ire):
+		return FailureReasonPermanent
+	case ire.statusCode == http.StatusNotFound:
+		return FailureReasonNotFound
+	case ire.statusCode == http.StatusTooManyRequests,
+		ire.statusCode >= http.StatusInternalServerError:
+		return FailureReasonTransient
+	}
+	return FailureReasonPermanent
+}
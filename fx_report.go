@@ -0,0 +1,251 @@
+package up
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FXReportPeriod defines how transactions are grouped over time in an
+// FXReport.
+type FXReportPeriod string
+
+const (
+	FXReportPeriodDay   FXReportPeriod = "DAY"   // Grouped by calendar day.
+	FXReportPeriodWeek  FXReportPeriod = "WEEK"  // Grouped by week, starting on Monday.
+	FXReportPeriodMonth FXReportPeriod = "MONTH" // Grouped by calendar month.
+	FXReportPeriodAll   FXReportPeriod = "ALL"   // All transactions in a single period.
+)
+
+// start returns the start of the period containing t, in t's location; convert
+// t to the report's location first, so transactions made either side of a
+// daylight saving change are grouped together.
+func (p FXReportPeriod) start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch p {
+	case FXReportPeriodDay:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	case FXReportPeriodWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+	case FXReportPeriodAll:
+		return time.Time{}
+	}
+	return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// FXReportOption configures an FXReport.
+type FXReportOption func(*fxReportConfig)
+
+// fxReportConfig holds the configuration for an FXReport.
+type fxReportConfig struct {
+	period    FXReportPeriod // How transactions are grouped over time.
+	location  *time.Location // The location periods start and end in.
+	threshold float64        // How far a rate may be from the period average before it's an outlier.
+}
+
+// fxPeriodKey identifies a period by the date it starts on.
+type fxPeriodKey struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// defaultFXReportLocation returns the location periods are in by default;
+// Sydney, or UTC if the time zone database isn't available.
+func defaultFXReportLocation() *time.Location {
+	loc, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// FXReportOptionPeriod sets how transactions are grouped over time. Defaults
+// to FXReportPeriodMonth.
+func FXReportOptionPeriod(p FXReportPeriod) FXReportOption {
+	return func(c *fxReportConfig) {
+		c.period = p
+	}
+}
+
+// FXReportOptionLocation sets the location periods start and end in; each
+// transaction is grouped by when it was created in this location, rather than
+// in its own UTC offset. Defaults to Australia/Sydney, or UTC if the time zone
+// database isn't available (see time/tzdata).
+func FXReportOptionLocation(loc *time.Location) FXReportOption {
+	return func(c *fxReportConfig) {
+		c.location = loc
+	}
+}
+
+// FXReportOptionOutlierThreshold sets how far a transaction's rate may be from
+// the average rate for its period, as a fraction of the average, before it's
+// flagged as an outlier. Defaults to 0.05 (5%).
+func FXReportOptionOutlierThreshold(threshold float64) FXReportOption {
+	return func(c *fxReportConfig) {
+		c.threshold = threshold
+	}
+}
+
+// FXTransaction is a foreign-currency transaction, with its implied exchange
+// rate.
+type FXTransaction struct {
+	Transaction TransactionDataWrapper
+
+	// Rate is the implied exchange rate, as the amount charged (usually in
+	// AUD) for one unit of the foreign currency.
+	Rate float64
+
+	// Deviation is how far Rate is from the average rate for the period, as a
+	// fraction of the average (eg. 0.1 is 10% above the average); zero if the
+	// average is zero.
+	Deviation float64
+
+	// Outlier is whether Deviation is beyond the configured threshold.
+	Outlier bool
+}
+
+// FXRateStats summarises spending in a foreign currency, and the exchange
+// rates implied by it.
+type FXRateStats struct {
+	ForeignTotal Money   // The total of the foreign amounts.
+	Total        Money   // The total of the amounts charged for them.
+	MinRate      float64 // The lowest implied rate.
+	AvgRate      float64 // The average implied rate, weighted by amount.
+	MaxRate      float64 // The highest implied rate.
+}
+
+// FXPeriodReport holds the foreign-currency transactions for a single period.
+type FXPeriodReport struct {
+	FXRateStats
+	Start        time.Time // The start of the period; zero for FXReportPeriodAll.
+	Transactions []FXTransaction
+}
+
+// FXCurrencyReport holds the transactions in a single foreign currency.
+type FXCurrencyReport struct {
+	FXRateStats
+	CurrencyCode string
+	Periods      []FXPeriodReport // In chronological order.
+}
+
+// FXReport is a report of spending in foreign currencies.
+type FXReport struct {
+	Currencies []FXCurrencyReport // In order of currency code.
+}
+
+// NewFXReport builds a report from the given transactions (eg. from
+// ListTransactions), of the spending in each foreign currency, with the
+// exchange rate implied by each transaction's Amount against its
+// ForeignAmount. Transactions without a foreign amount are ignored.
+func NewFXReport(
+	transactions []TransactionDataWrapper,
+	options ...FXReportOption,
+) (*FXReport, error) {
+
+	cfg := fxReportConfig{period: FXReportPeriodMonth, threshold: 0.05}
+	for _, o := range options {
+		o(&cfg)
+	}
+	if cfg.location == nil {
+		cfg.location = defaultFXReportLocation()
+	}
+
+	// group the transactions by currency, then by period.
+	byCurrency := make(map[string]map[fxPeriodKey]FXPeriodReport)
+	for _, t := range transactions {
+		a := t.Attributes
+		if a.ForeignAmount.CurrencyCode == "" || a.ForeignAmount.IsZero() {
+			continue
+		}
+		currency := a.ForeignAmount.CurrencyCode
+		if byCurrency[currency] == nil {
+			byCurrency[currency] = make(map[fxPeriodKey]FXPeriodReport)
+		}
+		start := cfg.period.start(a.CreatedAt.In(cfg.location))
+		y, m, d := start.Date()
+		key := fxPeriodKey{y, m, d}
+		pr := byCurrency[currency][key]
+		pr.Start = start
+		pr.Transactions = append(pr.Transactions, FXTransaction{
+			Transaction: t,
+			Rate:        impliedRate(a.Amount, a.ForeignAmount),
+		})
+		byCurrency[currency][key] = pr
+	}
+
+	// summarise each group.
+	report := &FXReport{}
+	for currency, periods := range byCurrency {
+		cr := FXCurrencyReport{CurrencyCode: currency}
+		var all []FXTransaction
+		for _, pr := range periods {
+			stats, err := newFXRateStats(pr.Transactions)
+			if err != nil {
+				return nil, err
+			}
+			pr.FXRateStats = stats
+			for i := range pr.Transactions {
+				t := &pr.Transactions[i]
+				if pr.AvgRate == 0 {
+					continue
+				}
+				t.Deviation = t.Rate/pr.AvgRate - 1
+				t.Outlier = math.Abs(t.Deviation) > cfg.threshold
+			}
+			cr.Periods = append(cr.Periods, pr)
+			all = append(all, pr.Transactions...)
+		}
+		stats, err := newFXRateStats(all)
+		if err != nil {
+			return nil, err
+		}
+		cr.FXRateStats = stats
+		slices.SortFunc(cr.Periods, func(a, b FXPeriodReport) int {
+			return a.Start.Compare(b.Start)
+		})
+		report.Currencies = append(report.Currencies, cr)
+	}
+	slices.SortFunc(report.Currencies, func(a, b FXCurrencyReport) int {
+		return strings.Compare(a.CurrencyCode, b.CurrencyCode)
+	})
+	return report, nil
+}
+
+// newFXRateStats summarises the given transactions, which must have at least
+// one entry.
+func newFXRateStats(txns []FXTransaction) (stats FXRateStats, err error) {
+	var charged, foreign float64
+	stats.MinRate, stats.MaxRate = math.Inf(1), math.Inf(-1)
+	for _, t := range txns {
+		a := t.Transaction.Attributes
+		if stats.ForeignTotal, err = stats.ForeignTotal.Add(a.ForeignAmount); err != nil {
+			return FXRateStats{}, err
+		}
+		if stats.Total, err = stats.Total.Add(a.Amount); err != nil {
+			return FXRateStats{}, err
+		}
+		stats.MinRate = min(stats.MinRate, t.Rate)
+		stats.MaxRate = max(stats.MaxRate, t.Rate)
+		charged += wholeUnits(a.Amount)
+		foreign += wholeUnits(a.ForeignAmount)
+	}
+
+	// weight by the size of each transaction, regardless of its direction, so
+	// refunds don't cancel out purchases.
+	stats.AvgRate = charged / foreign
+	return stats, nil
+}
+
+// impliedRate returns the amount of 'amount's currency paid for one unit of
+// 'foreign's currency.
+func impliedRate(amount, foreign Money) float64 {
+	return wholeUnits(amount) / wholeUnits(foreign)
+}
+
+// wholeUnits returns the absolute value of m in whole units of its currency.
+func wholeUnits(m Money) float64 {
+	return math.Abs(float64(m.ValueInBaseUnits)) / math.Pow10(currencyExponent(m.CurrencyCode))
+}
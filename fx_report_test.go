@@ -0,0 +1,142 @@
+package up
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// newFXTestTransaction returns a transaction charged 'amount' AUD cents for
+// 'foreign' base units of the given currency.
+func newFXTestTransaction(id string, created time.Time, amount int64, currency string, foreign int64) TransactionDataWrapper {
+	var t TransactionDataWrapper
+	t.ID = id
+	t.Attributes.CreatedAt = created
+	t.Attributes.Amount = NewMoney("AUD", amount)
+	if currency != "" {
+		t.Attributes.ForeignAmount = NewMoney(currency, foreign)
+	}
+	return t
+}
+
+func Test_NewFXReport(t *testing.T) {
+	var (
+		nov = time.Date(2024, 11, 3, 4, 0, 0, 0, location)
+		dec = time.Date(2024, 12, 3, 4, 0, 0, 0, location)
+	)
+	transactions := []TransactionDataWrapper{
+		newFXTestTransaction("local", nov, -1000, "", 0),
+		newFXTestTransaction("idr-1", nov, -10000, "IDR", -100000000), // 0.0001 AUD per IDR.
+		newFXTestTransaction("idr-2", nov, -10000, "IDR", -100000000),
+		newFXTestTransaction("idr-3", nov, -12000, "IDR", -100000000), // 12.5% over the average.
+		newFXTestTransaction("jpy-1", dec, -1000, "JPY", -1000),       // 0.01 AUD per JPY.
+		newFXTestTransaction("idr-4", dec, -5000, "IDR", -50000000),
+	}
+
+	got, err := NewFXReport(transactions, FXReportOptionOutlierThreshold(0.1))
+	if err != nil {
+		t.Fatalf("NewFXReport() returned an error;\nerror=%v\n", err)
+	}
+
+	// are the currencies and periods grouped as expected?
+	if len(got.Currencies) != 2 ||
+		got.Currencies[0].CurrencyCode != "IDR" ||
+		got.Currencies[1].CurrencyCode != "JPY" {
+		t.Fatalf("NewFXReport() returned unexpected currencies;\ngot=%+v\n", got.Currencies)
+	}
+	idr := got.Currencies[0]
+	if len(idr.Periods) != 2 || !idr.Periods[0].Start.Equal(time.Date(2024, 11, 1, 0, 0, 0, 0, location)) {
+		t.Fatalf("NewFXReport() returned unexpected periods;\ngot=%+v\n", idr.Periods)
+	}
+	if want := NewMoney("IDR", -350000000); idr.ForeignTotal != want {
+		t.Errorf("NewFXReport() returned unexpected foreign total;\nwant=%+v\ngot=%+v\n", want, idr.ForeignTotal)
+	}
+
+	// are the rates as expected?
+	nearly := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if p := idr.Periods[0]; !nearly(p.MinRate, 0.0001) ||
+		!nearly(p.MaxRate, 0.00012) ||
+		!nearly(p.AvgRate, 0.00032/3) {
+		t.Errorf("NewFXReport() returned unexpected rates;\ngot=%+v\n", p.FXRateStats)
+	}
+	if jpy := got.Currencies[1]; !nearly(jpy.AvgRate, 0.01) {
+		t.Errorf("NewFXReport() returned unexpected rate for JPY;\ngot=%v\n", jpy.AvgRate)
+	}
+
+	// are only the unusual rates flagged?
+	for _, tx := range idr.Periods[0].Transactions {
+		if want := tx.Transaction.ID == "idr-3"; tx.Outlier != want {
+			t.Errorf("NewFXReport() flagged %s unexpectedly;\nwant=%v\ngot=%v\n", tx.Transaction.ID, want, tx.Outlier)
+		}
+	}
+}
+
+func Test_NewFXReport_location(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skipf("time zone database isn't available; error=%v", err)
+	}
+
+	// daylight saving started on 2024-10-06, so these are made at different
+	// offsets, but in the same month in Sydney.
+	var (
+		aest      = time.FixedZone("AEST", 10*60*60)
+		aedt      = time.FixedZone("AEDT", 11*60*60)
+		lastNight = time.Date(2024, 10, 31, 14, 30, 0, 0, time.UTC) // 2024-11-01 01:30 in Sydney.
+	)
+	transactions := []TransactionDataWrapper{
+		newFXTestTransaction("before", time.Date(2024, 10, 2, 12, 0, 0, 0, aest), -1000, "USD", -700),
+		newFXTestTransaction("after", time.Date(2024, 10, 20, 12, 0, 0, 0, aedt), -1000, "USD", -700),
+		newFXTestTransaction("boundary", lastNight, -1000, "USD", -700),
+		newFXTestTransaction("free", time.Date(2024, 12, 2, 12, 0, 0, 0, aedt), 0, "JPY", -1000),
+	}
+
+	tests := map[string]struct {
+		options []FXReportOption
+		want    []time.Time // The start of each USD period.
+	}{
+		"sydney by default": {
+			want: []time.Time{
+				time.Date(2024, 10, 1, 0, 0, 0, 0, sydney),
+				time.Date(2024, 11, 1, 0, 0, 0, 0, sydney),
+			},
+		},
+		"utc": {
+			options: []FXReportOption{FXReportOptionLocation(time.UTC)},
+			want: []time.Time{
+				time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewFXReport(transactions, tt.options...)
+			if err != nil {
+				t.Fatalf("NewFXReport() returned an error;\nerror=%v\n", err)
+			}
+			if len(got.Currencies) != 2 {
+				t.Fatalf("NewFXReport() returned unexpected currencies;\ngot=%+v\n", got.Currencies)
+			}
+			usd := got.Currencies[1]
+			var starts []time.Time
+			for _, p := range usd.Periods {
+				starts = append(starts, p.Start)
+			}
+			if len(starts) != len(tt.want) {
+				t.Fatalf("NewFXReport() returned unexpected periods;\nwant=%v\ngot=%v\n", tt.want, starts)
+			}
+			for i := range starts {
+				if !starts[i].Equal(tt.want[i]) {
+					t.Errorf("NewFXReport() returned unexpected periods;\nwant=%v\ngot=%v\n", tt.want, starts)
+				}
+			}
+
+			// a period charged nothing has no average to deviate from.
+			for _, tx := range got.Currencies[0].Periods[0].Transactions {
+				if tx.Deviation != 0 || tx.Outlier {
+					t.Errorf("NewFXReport() returned a deviation for %s with no average rate;\ngot=%+v\n", tx.Transaction.ID, tx)
+				}
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package up
 
 import (
+	"context"
 	"reflect"
+	"sync"
+	"time"
 )
 
 // A helper function to check if an interface has a value or not.
@@ -17,3 +20,70 @@ func isNil(i interface{}) bool {
 	}
 	return false
 }
+
+// forEachConcurrently calls fn for every index in [0, n), using at most
+// 'workers' goroutines at once, and returns once every call has finished.
+// A workers value less than 1 is treated as 1.
+func forEachConcurrently(n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// rateLimiter spaces out calls to wait so that they return at most once every
+// interval. A nil or zero rateLimiter doesn't limit at all. A rateLimiter is
+// safe for concurrent use.
+type rateLimiter struct {
+	interval time.Duration
+	metrics  *clientMetrics // Records how long each call waited; nil to not record.
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the next call is allowed, or the context is done.
+func (r *rateLimiter) wait(ctx context.Context) error {
+	if r == nil || r.interval <= 0 {
+		return ctx.Err()
+	}
+	r.mu.Lock()
+	now := time.Now()
+	at := r.next
+	if at.Before(now) {
+		at = now
+	}
+	r.next = at.Add(r.interval)
+	r.mu.Unlock()
+
+	d := time.Until(at)
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		if r.metrics != nil {
+			r.metrics.recordRateLimitWait(ctx, max(d, 0))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,193 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// HydratedTransaction is a transaction with its account, transfer account,
+// category and parent category relationships resolved into full resources and
+// display names. Any relationship that is empty on the transaction (or that
+// couldn't be resolved in non-strict mode) is left nil, with an empty name.
+type HydratedTransaction struct {
+	Transaction TransactionDataWrapper
+
+	Account             *AccountDataWrapper
+	AccountName         string
+	TransferAccount     *AccountDataWrapper
+	TransferAccountName string
+	Category            *CategoryData
+	CategoryName        string
+	ParentCategory      *CategoryData
+	ParentCategoryName  string
+}
+
+// HydratorOption configures a Hydrator.
+type HydratorOption func(*Hydrator)
+
+// HydratorOptionStrict makes the Hydrator return an
+// ErrHydratorMissingReference whenever a transaction references an account or
+// category that couldn't be found, rather than leaving it unresolved.
+func HydratorOptionStrict() HydratorOption {
+	return func(h *Hydrator) {
+		h.strict = true
+	}
+}
+
+// Hydrator resolves the relationships of transactions into full resources.
+// Accounts and categories are fetched once, on first use, and cached for the
+// lifetime of the Hydrator - use Refresh to re-fetch them. A Hydrator is safe
+// for concurrent use.
+type Hydrator struct {
+	client *Client
+	strict bool
+
+	mu         sync.Mutex
+	loaded     bool
+	accounts   map[string]AccountDataWrapper
+	categories map[string]CategoryData
+}
+
+// NewHydrator returns a Hydrator that uses this client to look up accounts and
+// categories.
+func (c *Client) NewHydrator(options ...HydratorOption) *Hydrator {
+	h := &Hydrator{client: c}
+	for _, o := range options {
+		o(h)
+	}
+	return h
+}
+
+// Refresh (re-)fetches the accounts and categories used to resolve
+// transaction relationships.
+func (h *Hydrator) Refresh(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.load(ctx)
+}
+
+// load fetches the accounts and categories into the cache. The caller must
+// hold h.mu.
+func (h *Hydrator) load(ctx context.Context) error {
+	accounts, err := h.client.listAccounts(ctx, nil)
+	if err != nil {
+		return err
+	}
+	categories, err := h.client.ListCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.accounts = make(map[string]AccountDataWrapper, len(accounts))
+	for _, a := range accounts {
+		h.accounts[a.ID] = a
+	}
+	h.categories = make(map[string]CategoryData, len(categories))
+	for _, cat := range categories {
+		h.categories[cat.ID] = cat
+	}
+	h.loaded = true
+	return nil
+}
+
+// Hydrate resolves the relationships for each of the given transactions,
+// returning them in the same order.
+func (h *Hydrator) Hydrate(
+	ctx context.Context,
+	transactions []TransactionDataWrapper,
+) ([]HydratedTransaction, error) {
+
+	newCtx, span := h.client.startOperation(ctx, "Hydrate")
+	defer span.End()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.loaded {
+		if err := h.load(newCtx); err != nil {
+			span.SetStatus(codes.Error, fmt.Sprintf("failed to load hydrator cache: %v", err))
+			span.RecordError(err)
+			return nil, fmt.Errorf("loading hydrator cache: %w", err)
+		}
+	}
+
+	hydrated := make([]HydratedTransaction, 0, len(transactions))
+	for _, t := range transactions {
+		ht, err := h.hydrate(t)
+		if err != nil {
+			span.SetStatus(codes.Error, fmt.Sprintf("failed to hydrate transaction %s: %v", t.ID, err))
+			span.RecordError(err)
+			return nil, err
+		}
+		hydrated = append(hydrated, ht)
+	}
+	return hydrated, nil
+}
+
+// hydrate resolves the relationships for a single transaction. The caller
+// must hold h.mu.
+func (h *Hydrator) hydrate(t TransactionDataWrapper) (HydratedTransaction, error) {
+	ht := HydratedTransaction{Transaction: t}
+	r := t.Relationships
+
+	if id := r.Account.Data.ID; id != "" {
+		a, ok := h.accounts[id]
+		if ok {
+			ht.Account, ht.AccountName = &a, a.Attributes.DisplayName
+		} else if h.strict {
+			return ht, ErrHydratorMissingReference{t.ID, "account", id}
+		}
+	}
+	if id := r.TransferAccount.Data.ID; id != "" {
+		a, ok := h.accounts[id]
+		if ok {
+			ht.TransferAccount, ht.TransferAccountName = &a, a.Attributes.DisplayName
+		} else if h.strict {
+			return ht, ErrHydratorMissingReference{t.ID, "transferAccount", id}
+		}
+	}
+	if id := r.Category.Data.ID; id != "" {
+		cat, ok := h.categories[id]
+		if ok {
+			ht.Category, ht.CategoryName = &cat, cat.Attributes.Name
+		} else if h.strict {
+			return ht, ErrHydratorMissingReference{t.ID, "category", id}
+		}
+	}
+	if id := r.ParentCategory.Data.ID; id != "" {
+		cat, ok := h.categories[id]
+		if ok {
+			ht.ParentCategory, ht.ParentCategoryName = &cat, cat.Attributes.Name
+		} else if h.strict {
+			return ht, ErrHydratorMissingReference{t.ID, "parentCategory", id}
+		}
+	}
+	return ht, nil
+}
+
+// ListTransactions lists transactions (see Client.ListTransactions) and
+// hydrates them.
+func (h *Hydrator) ListTransactions(
+	ctx context.Context,
+	options ...ListTransactionsOption,
+) ([]HydratedTransaction, error) {
+	txns, err := h.client.ListTransactions(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return h.Hydrate(ctx, txns)
+}
+
+// ListTransactionsHydrated returns all transactions across all accounts, with
+// their account and category relationships resolved. Accounts and categories
+// are looked up once for the whole call; references that can't be resolved
+// are left unset. Use NewHydrator with HydratorOptionStrict to treat them as
+// errors, or to reuse the lookups across calls.
+func (c *Client) ListTransactionsHydrated(
+	ctx context.Context,
+	options ...ListTransactionsOption,
+) ([]HydratedTransaction, error) {
+	return c.NewHydrator().ListTransactions(ctx, options...)
+}
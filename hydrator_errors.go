@@ -0,0 +1,20 @@
+package up
+
+import "fmt"
+
+// ErrHydratorMissingReference is returned by a strict Hydrator when a
+// transaction references an account or category that couldn't be found.
+type ErrHydratorMissingReference struct {
+	TransactionID string // The ID of the transaction holding the reference.
+	Relationship  string // The relationship that couldn't be resolved (eg. "category").
+	ID            string // The ID that was referenced.
+}
+
+func (e ErrHydratorMissingReference) Error() string {
+	return fmt.Sprintf(
+		"transaction %s references unknown %s %q",
+		e.TransactionID,
+		e.Relationship,
+		e.ID,
+	)
+}
@@ -0,0 +1,140 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var categoriesTestdata = newTestdata("categories")
+
+// hydratorMock serves the accounts and categories fixtures used to resolve
+// transaction relationships.
+var hydratorMock = &mockRoundTripper{
+	MockFunc: func(req *http.Request) *http.Response {
+		var b []byte
+		switch {
+		case strings.HasPrefix(req.URL.Path, "/api/v1/accounts"):
+			b = accountsTestdata[0].content
+			for i := 0; i < len(accountsTestdata); i++ {
+				if strings.Contains(req.URL.String(), fmt.Sprintf("---%v", i+1)) {
+					b = accountsTestdata[i].content
+					break
+				}
+			}
+		case strings.HasPrefix(req.URL.Path, "/api/v1/categories"):
+			b = categoriesTestdata.content
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(b)),
+			Header:     make(http.Header),
+		}
+	},
+}
+
+// newHydratorTestTransaction returns a transaction referencing the given
+// account and categories.
+func newHydratorTestTransaction(account, category, parent string) TransactionDataWrapper {
+	var t TransactionDataWrapper
+	t.ID = "txn"
+	t.Relationships.Account.Data = Object{Type: "accounts", ID: account}
+	t.Relationships.Category.Data = Object{Type: "categories", ID: category}
+	t.Relationships.ParentCategory.Data = Object{Type: "categories", ID: parent}
+	return t
+}
+
+func Test_Hydrate(t *testing.T) {
+	tests := map[string]struct {
+		options      []HydratorOption
+		transactions []TransactionDataWrapper
+		want         []HydratedTransaction
+		err          string
+	}{
+		"resolve account and category": {
+			transactions: []TransactionDataWrapper{
+				newHydratorTestTransaction("4ed1d99c-ce10-4b54-952f-05151c2ab423", "hobbies", ""),
+			},
+			want: []HydratedTransaction{
+				{AccountName: "Spending", CategoryName: "Hobbies"},
+			},
+		},
+		"resolve accounts across pages": {
+			transactions: []TransactionDataWrapper{
+				newHydratorTestTransaction("3c0e937b-6cbf-4db6-b302-c54a65775c01", "", ""),
+			},
+			want: []HydratedTransaction{
+				{AccountName: "Home Loan"},
+			},
+		},
+		"missing reference is ignored": {
+			transactions: []TransactionDataWrapper{
+				newHydratorTestTransaction("4ed1d99c-ce10-4b54-952f-05151c2ab423", "hobbies", "good-life"),
+			},
+			want: []HydratedTransaction{
+				{AccountName: "Spending", CategoryName: "Hobbies"},
+			},
+		},
+		"missing reference is an error when strict": {
+			options: []HydratorOption{HydratorOptionStrict()},
+			transactions: []TransactionDataWrapper{
+				newHydratorTestTransaction("4ed1d99c-ce10-4b54-952f-05151c2ab423", "hobbies", "good-life"),
+			},
+			err: ErrHydratorMissingReference{"txn", "parentCategory", "good-life"}.Error(),
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		c := newTestClient(t, hydratorMock)
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			got, err := c.NewHydrator(tt.options...).Hydrate(ctx, tt.transactions)
+
+			// any errors?
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf(
+						"Hydrate() returned an unexpected error;\nwant=%v\ngot=%v\n",
+						tt.err,
+						err,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Hydrate() returned an error;\nerror=%v\n", err)
+				return
+			}
+
+			// do the lengths match?
+			if len(got) != len(tt.want) {
+				t.Errorf(
+					"Hydrate() returned unexpected number of results;\nwant=%d\ngot=%d\n",
+					len(tt.want),
+					len(got),
+				)
+				return
+			}
+
+			// is there a mismatch from what we're expecting vs what we've got?
+			for i := 0; i < len(got); i++ {
+				g, w := got[i], tt.want[i]
+				if g.AccountName != w.AccountName ||
+					g.TransferAccountName != w.TransferAccountName ||
+					g.CategoryName != w.CategoryName ||
+					g.ParentCategoryName != w.ParentCategoryName {
+					t.Errorf("mismatch at index %d;\nwant=%+v\ngot=%+v\n", i, w, g)
+				}
+			}
+		})
+	}
+}
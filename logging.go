@@ -0,0 +1,283 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// LogRedaction defines what is redacted from logs, as a set of flags that can
+// be combined (eg. LogRedactionAmounts|LogRedactionIDs).
+type LogRedaction uint
+
+const (
+	LogRedactionNone     LogRedaction = 0         // Nothing is redacted.
+	LogRedactionAmounts  LogRedaction = 1 << iota // Amounts and balances are masked.
+	LogRedactionIDs                               // IDs, and links containing them, are replaced with a hash.
+	LogRedactionFreeText                          // Free text (eg. descriptions, messages, notes and names) is dropped.
+
+	LogRedactionAll = LogRedactionAmounts | LogRedactionIDs | LogRedactionFreeText // Everything above is redacted.
+)
+
+// The keys of attributes redacted for each LogRedaction; these match the JSON
+// field names used by the API, and the keys used by this package's LogValue
+// methods.
+var (
+	amountLogKeys   = []string{"value", "valueInBaseUnits"}
+	idLogKeys       = []string{"id", "self", "related", "next", "prev"}
+	freeTextLogKeys = []string{"description", "message", "rawText", "text", "displayName", "cardNumberSuffix", "deepLinkURL"}
+)
+
+// levelHandler is a slog.Handler that drops records below a minimum level
+// before passing them on to the handler of slog.Default(), looked up for each
+// record so a later slog.SetDefault is respected. A record is only enabled if
+// it's at or above the minimum level and the default handler enables it too,
+// so WithLogLevel(slog.LevelDebug) only logs debug records if slog.Default()
+// is at slog.LevelDebug as well.
+type levelHandler struct {
+	level slog.Level
+	with  []func(slog.Handler) slog.Handler // Applied, in order, to the default handler.
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && slog.Default().Handler().Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	next := slog.Default().Handler()
+	for _, w := range h.with {
+		next = w(next)
+	}
+	return next.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.add(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return h.add(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+// add returns a copy of the handler, with the given function also applied to
+// the default handler.
+func (h *levelHandler) add(w func(slog.Handler) slog.Handler) slog.Handler {
+	return &levelHandler{h.level, append(slices.Clip(h.with), w)}
+}
+
+// redactingHandler is a slog.Handler that redacts attributes before passing
+// them on to another handler.
+type redactingHandler struct {
+	next      slog.Handler
+	redaction LogRedaction
+	client    bool // Whether this was added by a client, from WithLogRedaction.
+}
+
+// NewRedactingHandler returns a slog.Handler that redacts attributes, by key,
+// before passing them on to the given handler. It redacts the values of the
+// types in this package logged through it (eg. TransactionResource), so can
+// be used to redact them from an application's own logs.
+func NewRedactingHandler(next slog.Handler, redaction LogRedaction) slog.Handler {
+	return &redactingHandler{next: next, redaction: redaction}
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a = h.redact(a); !a.Equal(slog.Attr{}) {
+			redacted.AddAttrs(a)
+		}
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if a = h.redact(a); !a.Equal(slog.Attr{}) {
+			redacted = append(redacted, a)
+		}
+	}
+	return &redactingHandler{h.next.WithAttrs(redacted), h.redaction, h.client}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{h.next.WithGroup(name), h.redaction, h.client}
+}
+
+// redact returns the given attribute with its value redacted, or an empty
+// attribute if it should be dropped.
+func (h *redactingHandler) redact(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	switch {
+	case strings.EqualFold(a.Key, "Authorization"):
+		return slog.String(a.Key, "[REDACTED]")
+	case a.Value.Kind() == slog.KindGroup:
+		var attrs []slog.Attr
+		for _, ga := range a.Value.Group() {
+			if ga = h.redact(ga); !ga.Equal(slog.Attr{}) {
+				attrs = append(attrs, ga)
+			}
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(attrs...)}
+	case h.redaction&LogRedactionAmounts != 0 && slices.Contains(amountLogKeys, a.Key):
+		return slog.String(a.Key, "***")
+	case h.redaction&LogRedactionIDs != 0 && slices.Contains(idLogKeys, a.Key):
+		sum := sha256.Sum256([]byte(a.Value.String()))
+		return slog.String(a.Key, "sha256:"+hex.EncodeToString(sum[:6]))
+	case h.redaction&LogRedactionFreeText != 0 && slices.Contains(freeTextLogKeys, a.Key):
+		return slog.Attr{}
+	}
+	return a
+}
+
+// jsonLogValue returns the given JSON as a slog.Value, with objects and arrays
+// as groups, so it can be redacted by key. Anything that isn't valid JSON is
+// returned as a string.
+func jsonLogValue(b []byte) slog.Value {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return slog.StringValue(string(b))
+	}
+	return jsonToLogValue(v)
+}
+
+// jsonToLogValue returns the given decoded JSON value as a slog.Value.
+func jsonToLogValue(v interface{}) slog.Value {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		attrs := make([]slog.Attr, 0, len(v))
+		for _, k := range keys {
+			attrs = append(attrs, slog.Attr{Key: k, Value: jsonToLogValue(v[k])})
+		}
+		return slog.GroupValue(attrs...)
+	case []interface{}:
+		attrs := make([]slog.Attr, 0, len(v))
+		for i, e := range v {
+			attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: jsonToLogValue(e)})
+		}
+		return slog.GroupValue(attrs...)
+	case json.Number:
+		return slog.StringValue(v.String())
+	case nil:
+		return slog.AnyValue(nil)
+	}
+	return slog.AnyValue(v)
+}
+
+// loggerFor returns the client's logger, tagged with the correlation ID,
+// operation name and trace and span IDs of the call in the given context.
+func (c *Client) loggerFor(ctx context.Context) *slog.Logger {
+	attrs := []any{"operation", operationFrom(ctx)}
+	if id, ok := ctx.Value(correlationIDKey{}).(string); ok {
+		attrs = append(attrs, "correlation_id", id)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		attrs = append(attrs, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+	return c.logger.With(attrs...)
+}
+
+// bodyLogValue returns the given response body as a slog.Value; as a group
+// when logs are being redacted, so it can be redacted by key.
+func (c *Client) bodyLogValue(b []byte) slog.Value {
+	if c.logRedaction == LogRedactionNone {
+		return slog.StringValue(string(b))
+	}
+	return jsonLogValue(b)
+}
+
+// LogValue implements slog.LogValuer, so Money can be redacted when logged.
+func (m Money) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("currencyCode", m.CurrencyCode),
+		slog.String("value", m.Value),
+	)
+}
+
+// LogValue implements slog.LogValuer, so a transaction can be redacted when
+// logged. Empty fields are left out.
+func (t TransactionResource) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("status", string(t.Status)),
+		slog.String("description", t.Description),
+		slog.Any("amount", t.Amount),
+		slog.Time("createdAt", t.CreatedAt),
+	}
+	if t.ForeignAmount.CurrencyCode != "" {
+		attrs = append(attrs, slog.Any("foreignAmount", t.ForeignAmount))
+	}
+	if t.TransactionType != "" {
+		attrs = append(attrs, slog.String("transactionType", string(t.TransactionType)))
+	}
+	for _, s := range []struct{ key, value string }{
+		{"rawText", t.RawText},
+		{"message", t.Message},
+		{"text", t.Note.Text},
+		{"displayName", t.PerformingCustomer.DisplayName},
+	} {
+		if s.value != "" {
+			attrs = append(attrs, slog.String(s.key, s.value))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer, so a transaction can be redacted when
+// logged.
+func (t TransactionDataWrapper) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", t.ID),
+		slog.Any("attributes", t.Attributes),
+	)
+}
+
+// LogValue implements slog.LogValuer, so an account can be redacted when
+// logged.
+func (a AccountResource) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("displayName", a.DisplayName),
+		slog.String("accountType", string(a.AccountType)),
+		slog.String("ownershipType", string(a.OwnershipType)),
+		slog.Any("balance", a.Balance),
+		slog.Time("createdAt", a.CreatedAt),
+	)
+}
+
+// LogValue implements slog.LogValuer, so an account can be redacted when
+// logged.
+func (a AccountDataWrapper) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", a.ID),
+		slog.Any("attributes", a.Attributes),
+	)
+}
+
+// LogValue implements slog.LogValuer, so a client can be logged without
+// leaking its token.
+func (c *Client) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("endpoint", c.endpoint),
+		slog.String("decodingMode", string(c.decodingMode)),
+	)
+}
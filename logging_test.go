@@ -0,0 +1,205 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_NewRedactingHandler(t *testing.T) {
+	var txn struct {
+		Data TransactionDataWrapper `json:"data"`
+	}
+	if err := json.Unmarshal(transactionTestdata.content, &txn); err != nil {
+		t.Fatalf("failed to unmarshal testdata; error=%v", err)
+	}
+	tests := map[string]struct {
+		redaction LogRedaction
+		want      []string
+		unwanted  []string
+	}{
+		"none": {
+			redaction: LogRedactionNone,
+			want:      []string{"Warung Bebek Bengil", "-107.92", "6fff09f5-be7d-4ae1-9f71-4a25440bc405"},
+			unwanted:  []string{"Bearer xxxx"},
+		},
+		"amounts": {
+			redaction: LogRedactionAmounts,
+			want:      []string{"Warung Bebek Bengil", `"value":"***"`, `"currencyCode":"AUD"`},
+			unwanted:  []string{"-107.92", "Bearer xxxx"},
+		},
+		"ids": {
+			redaction: LogRedactionIDs,
+			want:      []string{"Warung Bebek Bengil", `"id":"sha256:`},
+			unwanted:  []string{"6fff09f5-be7d-4ae1-9f71-4a25440bc405", "Bearer xxxx"},
+		},
+		"free text": {
+			redaction: LogRedactionFreeText,
+			want:      []string{"-107.92", `"status":"SETTLED"`},
+			unwanted:  []string{"Warung Bebek Bengil", "WARUNG BEBEK", "Bearer xxxx"},
+		},
+		"all": {
+			redaction: LogRedactionAll,
+			want:      []string{`"status":"SETTLED"`},
+			unwanted:  []string{"Warung Bebek Bengil", "-107.92", "6fff09f5-be7d-4ae1-9f71-4a25440bc405", "Bearer xxxx"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(NewRedactingHandler(slog.NewJSONHandler(&buf, nil), tt.redaction))
+			logger.With("Authorization", "Bearer xxxx").Info("transaction", "transaction", txn.Data)
+			got := buf.String()
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("log is missing %q;\ngot=%v\n", w, got)
+				}
+			}
+			for _, u := range tt.unwanted {
+				if strings.Contains(got, u) {
+					t.Errorf("log contains %q;\ngot=%v\n", u, got)
+				}
+			}
+		})
+	}
+}
+
+func Test_WithLogRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	c, err := New(context.Background(), "xxxx",
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithLogRedaction(LogRedactionAll),
+		WithSkipAuthCheck(),
+		WithHttpClient(&http.Client{Transport: &mockRoundTripper{
+			MockFunc: func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(transactionTestdata.content)),
+					Header:     make(http.Header),
+				}
+			},
+		}}),
+	)
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+	if _, err := c.GetTransaction(context.Background(), "6fff09f5-be7d-4ae1-9f71-4a25440bc405"); err != nil {
+		t.Fatalf("GetTransaction() returned an error; error=%v", err)
+	}
+	got := buf.String()
+	if !strings.Contains(got, `"valueInBaseUnits":"***"`) {
+		t.Errorf("response body wasn't logged;\ngot=%v\n", got)
+	}
+	for _, u := range []string{"Warung Bebek Bengil", "-107.92", "6fff09f5-be7d-4ae1-9f71-4a25440bc405", "xxxx"} {
+		if strings.Contains(got, u) {
+			t.Errorf("log contains %q;\ngot=%v\n", u, got)
+		}
+	}
+
+	// a derived client replaces the redaction, rather than adding to it.
+	buf.Reset()
+	d, err := c.With(WithLogRedaction(LogRedactionAmounts))
+	if err != nil {
+		t.Fatalf("With() returned an error; error=%v", err)
+	}
+	if _, err := d.GetTransaction(context.Background(), "6fff09f5-be7d-4ae1-9f71-4a25440bc405"); err != nil {
+		t.Fatalf("GetTransaction() returned an error; error=%v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "Warung Bebek Bengil") || strings.Contains(got, "-107.92") {
+		t.Errorf("derived client redacted unexpectedly;\ngot=%v\n", got)
+	}
+}
+
+func Test_defaultLogger(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	tests := map[string]struct {
+		level        slog.Level
+		defaultLevel slog.Level
+		want         bool
+	}{
+		"debug":                   {level: slog.LevelDebug, defaultLevel: slog.LevelDebug, want: true},
+		"debug with info default": {level: slog.LevelDebug, defaultLevel: slog.LevelInfo, want: false},
+		"warn":                    {level: slog.LevelWarn, defaultLevel: slog.LevelDebug, want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: tt.defaultLevel})))
+			c, err := New(context.Background(), "xxxx",
+				WithLogLevel(tt.level),
+				WithHttpClient(&http.Client{Transport: pingMockTransport}),
+			)
+			if err != nil {
+				t.Fatalf("New() returned an error; error=%v", err)
+			}
+			if got := strings.Contains(buf.String(), "client setup successfully"); got != tt.want {
+				t.Errorf("New() logged unexpectedly to slog.Default(); want=%v, got=%v", tt.want, buf.String())
+			}
+
+			// a default set after the client was created is used too.
+			var later bytes.Buffer
+			slog.SetDefault(slog.New(slog.NewJSONHandler(&later, &slog.HandlerOptions{Level: tt.defaultLevel})))
+			buf.Reset()
+			if _, err := c.Ping(context.Background()); err != nil {
+				t.Fatalf("Ping() returned an error; error=%v", err)
+			}
+			if buf.Len() > 0 || (later.Len() > 0) != tt.want {
+				t.Errorf("Ping() logged to an unexpected logger; want=%v, before=%v, later=%v", tt.want, buf.String(), later.String())
+			}
+		})
+	}
+}
+
+func Test_loggerFor(t *testing.T) {
+	var buf bytes.Buffer
+	recorder := tracetest.NewSpanRecorder()
+	c, err := New(context.Background(), "xxxx",
+		WithLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		WithHttpClient(&http.Client{Transport: pingMockTransport}),
+		WithSkipAuthCheck(),
+	)
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+	buf.Reset()
+	if _, err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() returned an error; error=%v", err)
+	}
+
+	// the log line from the sender is joined to its span, and the operation.
+	var got struct {
+		Operation     string `json:"operation"`
+		CorrelationID string `json:"correlation_id"`
+		TraceID       string `json:"trace_id"`
+		SpanID        string `json:"span_id"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal log line; error=%v", err)
+	}
+	spans := recorder.Ended()
+	sender, ping := spans[len(spans)-2], spans[len(spans)-1]
+	var correlationID string
+	for _, kv := range ping.Attributes() {
+		if kv.Key == "up.correlation_id" {
+			correlationID = kv.Value.AsString()
+		}
+	}
+	switch {
+	case
+		got.Operation != "Ping",
+		got.CorrelationID == "",
+		got.CorrelationID != correlationID,
+		got.TraceID != sender.SpanContext().TraceID().String(),
+		got.SpanID != sender.SpanContext().SpanID().String():
+		t.Errorf("sender logged unexpected correlation;\ngot=%+v\nspans=%v,%v\n", got, sender.SpanContext(), ping.SpanContext())
+	}
+}
@@ -1,10 +1,203 @@
 package up
 
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
 // Money represents the default attributes for any money-related data returned
 // from the API. This is largely used to capture the amount of funds returned
 // for transactions, or for listing the amount of funds available in an account.
+//
+// All arithmetic on Money is done on ValueInBaseUnits, so is exact. The zero
+// Money (with no currency code) acts as zero in any currency, so it can be used
+// as the starting point when summing amounts.
 type Money struct {
 	CurrencyCode     string `json:"currencyCode"`
 	Value            string `json:"value"`
 	ValueInBaseUnits int64  `json:"valueInBaseUnits"`
 }
+
+// NewMoney returns Money for the given number of base units (eg. cents) of the
+// given currency, with Value set to match. The number of decimal places in
+// Value comes from the currency's ISO 4217 exponent.
+func NewMoney(currencyCode string, valueInBaseUnits int64) Money {
+	return Money{
+		CurrencyCode:     currencyCode,
+		Value:            formatBaseUnits(valueInBaseUnits, currencyExponent(currencyCode), ""),
+		ValueInBaseUnits: valueInBaseUnits,
+	}
+}
+
+// formatBaseUnits formats the given base units as a decimal with 'exponent'
+// decimal places, with the whole part grouped in thousands by 'sep'.
+func formatBaseUnits(units int64, exponent int, sep string) string {
+	sign, abs := "", strconv.FormatUint(uint64(units), 10)
+	if units < 0 {
+		sign, abs = "-", strconv.FormatUint(uint64(-units), 10)
+	}
+	if len(abs) <= exponent {
+		abs = strings.Repeat("0", exponent-len(abs)+1) + abs
+	}
+	whole, frac := abs[:len(abs)-exponent], abs[len(abs)-exponent:]
+	if sep != "" {
+		var b strings.Builder
+		for i, r := range whole {
+			if i > 0 && (len(whole)-i)%3 == 0 {
+				b.WriteString(sep)
+			}
+			b.WriteRune(r)
+		}
+		whole = b.String()
+	}
+	if frac == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + frac
+}
+
+// ParseMoney returns Money for the given decimal value (eg. "-107.92") of the
+// given currency, with ValueInBaseUnits set to match. An ErrMoneyInvalidValue
+// is returned if the value isn't a decimal, or has more decimal places than
+// the currency's ISO 4217 exponent allows.
+func ParseMoney(currencyCode string, value string) (Money, error) {
+	units, err := parseBaseUnits(value, currencyExponent(currencyCode))
+	if err != nil {
+		return Money{}, ErrMoneyInvalidValue{currencyCode, value, err}
+	}
+	return Money{CurrencyCode: currencyCode, Value: value, ValueInBaseUnits: units}, nil
+}
+
+// parseBaseUnits parses the given decimal into base units, given the number
+// of decimal places in a whole unit.
+func parseBaseUnits(value string, exponent int) (int64, error) {
+	whole, frac, _ := strings.Cut(value, ".")
+	if len(frac) > exponent {
+		return 0, fmt.Errorf("more than %d decimal places", exponent)
+	}
+	if strings.ContainsAny(frac, "+-") {
+		return 0, fmt.Errorf("invalid decimal")
+	}
+	return strconv.ParseInt(whole+frac+strings.Repeat("0", exponent-len(frac)), 10, 64)
+}
+
+// Validate checks that m's Value and ValueInBaseUnits agree, given the
+// currency's ISO 4217 exponent, returning an ErrMoneyInvalidValue if they
+// don't. The zero Money (eg. a null amount from the API) is always valid.
+func (m Money) Validate() error {
+	if m == (Money{}) {
+		return nil
+	}
+	if _, ok := LookupCurrency(m.CurrencyCode); !ok {
+		return ErrMoneyInvalidValue{m.CurrencyCode, m.Value, fmt.Errorf("unknown currency")}
+	}
+	units, err := parseBaseUnits(m.Value, currencyExponent(m.CurrencyCode))
+	if err != nil {
+		return ErrMoneyInvalidValue{m.CurrencyCode, m.Value, err}
+	}
+	if units != m.ValueInBaseUnits {
+		return ErrMoneyInvalidValue{
+			m.CurrencyCode,
+			m.Value,
+			fmt.Errorf("value doesn't match %d base units", m.ValueInBaseUnits),
+		}
+	}
+	return nil
+}
+
+// currencyOf returns the currency shared by a and b, treating the zero Money
+// as having any currency.
+func currencyOf(a, b Money) (string, error) {
+	switch {
+	case a.CurrencyCode == b.CurrencyCode:
+		return a.CurrencyCode, nil
+	case a == Money{}:
+		return b.CurrencyCode, nil
+	case b == Money{}:
+		return a.CurrencyCode, nil
+	}
+	return "", ErrMoneyCurrencyMismatch{a.CurrencyCode, b.CurrencyCode}
+}
+
+// Add returns m + o. An ErrMoneyCurrencyMismatch is returned if the
+// currencies differ, or an ErrMoneyOverflow if the result doesn't fit.
+func (m Money) Add(o Money) (Money, error) {
+	currency, err := currencyOf(m, o)
+	if err != nil {
+		return Money{}, err
+	}
+	sum := m.ValueInBaseUnits + o.ValueInBaseUnits
+	if (o.ValueInBaseUnits > 0 && sum < m.ValueInBaseUnits) ||
+		(o.ValueInBaseUnits < 0 && sum > m.ValueInBaseUnits) {
+		return Money{}, ErrMoneyOverflow{}
+	}
+	return NewMoney(currency, sum), nil
+}
+
+// Sub returns m - o. An ErrMoneyCurrencyMismatch is returned if the
+// currencies differ, or an ErrMoneyOverflow if the result doesn't fit.
+func (m Money) Sub(o Money) (Money, error) {
+	if o.ValueInBaseUnits == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow{}
+	}
+	return m.Add(o.Neg())
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	if m == (Money{}) {
+		return m
+	}
+	return NewMoney(m.CurrencyCode, -m.ValueInBaseUnits)
+}
+
+// Abs returns the absolute value of m.
+func (m Money) Abs() Money {
+	if m.ValueInBaseUnits < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Cmp compares m and o, returning -1 if m < o, 0 if m == o, and +1 if m > o.
+// An ErrMoneyCurrencyMismatch is returned if the currencies differ.
+func (m Money) Cmp(o Money) (int, error) {
+	if _, err := currencyOf(m, o); err != nil {
+		return 0, err
+	}
+	return cmp.Compare(m.ValueInBaseUnits, o.ValueInBaseUnits), nil
+}
+
+// IsZero reports whether m is zero, in any currency.
+func (m Money) IsZero() bool {
+	return m.ValueInBaseUnits == 0
+}
+
+// Display formats m for display, eg. "-$107.92" for AUD, or
+// "IDR -1,053,698.77" for other currencies, since their symbols may be
+// ambiguous (eg. "$" for USD).
+func (m Money) Display() string {
+	value := formatBaseUnits(m.ValueInBaseUnits, currencyExponent(m.CurrencyCode), ",")
+	if m.CurrencyCode == "AUD" {
+		c, _ := LookupCurrency(m.CurrencyCode)
+		if strings.HasPrefix(value, "-") {
+			return "-" + c.Symbol + value[1:]
+		}
+		return c.Symbol + value
+	}
+	return fmt.Sprintf("%s %s", m.CurrencyCode, value)
+}
+
+// Sum returns the total of the given amounts, which must all be in the same
+// currency. The zero Money is returned if no amounts are given.
+func Sum(amounts ...Money) (total Money, err error) {
+	for _, a := range amounts {
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
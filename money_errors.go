@@ -0,0 +1,34 @@
+package up
+
+import "fmt"
+
+// ErrMoneyCurrencyMismatch is returned when combining or comparing Money in
+// different currencies.
+type ErrMoneyCurrencyMismatch struct {
+	A, B string // The currency codes involved.
+}
+
+func (e ErrMoneyCurrencyMismatch) Error() string {
+	return fmt.Sprintf("mismatched currencies: %s and %s", e.A, e.B)
+}
+
+// ErrMoneyOverflow is returned when the result of arithmetic on Money doesn't
+// fit in an int64 number of base units.
+type ErrMoneyOverflow struct {
+}
+
+func (e ErrMoneyOverflow) Error() string {
+	return "money value overflows int64 base units"
+}
+
+// ErrMoneyInvalidValue is returned when a Money value can't be parsed, or
+// doesn't agree with its value in base units.
+type ErrMoneyInvalidValue struct {
+	CurrencyCode string
+	Value        string
+	err          error
+}
+
+func (e ErrMoneyInvalidValue) Error() string {
+	return fmt.Sprintf("invalid %s money value %q: %v", e.CurrencyCode, e.Value, e.err)
+}
@@ -0,0 +1,235 @@
+package up
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_NewMoney(t *testing.T) {
+	tests := map[string]struct {
+		currencyCode string
+		units        int64
+		want         Money
+	}{
+		"positive": {
+			currencyCode: "AUD",
+			units:        142200,
+			want:         Money{"AUD", "1422.00", 142200},
+		},
+		"negative": {
+			currencyCode: "AUD",
+			units:        -10792,
+			want:         Money{"AUD", "-107.92", -10792},
+		},
+		"less than one": {
+			currencyCode: "AUD",
+			units:        -8,
+			want:         Money{"AUD", "-0.08", -8},
+		},
+		"zero": {
+			currencyCode: "AUD",
+			want:         Money{"AUD", "0.00", 0},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NewMoney(tt.currencyCode, tt.units); got != tt.want {
+				t.Errorf("NewMoney() returned unexpected value;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_MoneyArithmetic(t *testing.T) {
+	var (
+		aud10  = NewMoney("AUD", 1000)
+		aud5   = NewMoney("AUD", 500)
+		usd5   = NewMoney("USD", 500)
+		audNeg = NewMoney("AUD", -250)
+	)
+	tests := map[string]struct {
+		fn   func() (Money, error)
+		want Money
+		err  error
+	}{
+		"add": {
+			fn:   func() (Money, error) { return aud10.Add(aud5) },
+			want: NewMoney("AUD", 1500),
+		},
+		"add to zero": {
+			fn:   func() (Money, error) { return Money{}.Add(usd5) },
+			want: usd5,
+		},
+		"add mismatched currencies": {
+			fn:  func() (Money, error) { return aud10.Add(usd5) },
+			err: ErrMoneyCurrencyMismatch{"AUD", "USD"},
+		},
+		"sub": {
+			fn:   func() (Money, error) { return aud5.Sub(aud10) },
+			want: NewMoney("AUD", -500),
+		},
+		"add overflow": {
+			fn:  func() (Money, error) { return NewMoney("AUD", 1<<62).Add(NewMoney("AUD", 1<<62)) },
+			err: ErrMoneyOverflow{},
+		},
+		"neg": {
+			fn:   func() (Money, error) { return audNeg.Neg(), nil },
+			want: NewMoney("AUD", 250),
+		},
+		"abs": {
+			fn:   func() (Money, error) { return audNeg.Abs(), nil },
+			want: NewMoney("AUD", 250),
+		},
+		"sum": {
+			fn:   func() (Money, error) { return Sum(aud10, aud5, audNeg) },
+			want: NewMoney("AUD", 1250),
+		},
+		"sum nothing": {
+			fn:   func() (Money, error) { return Sum() },
+			want: Money{},
+		},
+		"sum mismatched currencies": {
+			fn:  func() (Money, error) { return Sum(aud10, usd5) },
+			err: ErrMoneyCurrencyMismatch{"AUD", "USD"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.fn()
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("returned an unexpected error;\nwant=%v\ngot=%v\n", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("returned an error;\nerror=%v\n", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("returned unexpected value;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_MoneyCmp(t *testing.T) {
+	tests := map[string]struct {
+		a, b Money
+		want int
+		err  error
+	}{
+		"less":     {a: NewMoney("AUD", -1), b: NewMoney("AUD", 1), want: -1},
+		"equal":    {a: NewMoney("AUD", 1), b: NewMoney("AUD", 1), want: 0},
+		"greater":  {a: NewMoney("AUD", 2), b: NewMoney("AUD", 1), want: 1},
+		"mismatch": {a: NewMoney("AUD", 2), b: NewMoney("USD", 1), err: ErrMoneyCurrencyMismatch{"AUD", "USD"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.a.Cmp(tt.b)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Cmp() returned an unexpected error;\nwant=%v\ngot=%v\n", tt.err, err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Cmp() returned unexpected value;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_MoneyDisplay(t *testing.T) {
+	tests := map[string]struct {
+		money Money
+		want  string
+	}{
+		"aud":            {money: NewMoney("AUD", -10792), want: "-$107.92"},
+		"aud thousands":  {money: NewMoney("AUD", 123456789), want: "$1,234,567.89"},
+		"other currency": {money: NewMoney("IDR", -105369877), want: "IDR -1,053,698.77"},
+		"no decimals":    {money: NewMoney("JPY", 123456), want: "JPY 123,456"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tt.money.Display(); got != tt.want {
+				t.Errorf("Display() returned unexpected value;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_ParseMoney(t *testing.T) {
+	tests := map[string]struct {
+		currencyCode string
+		value        string
+		want         Money
+		err          bool
+	}{
+		"aud":                 {currencyCode: "AUD", value: "-107.92", want: Money{"AUD", "-107.92", -10792}},
+		"less than one":       {currencyCode: "AUD", value: "-0.08", want: Money{"AUD", "-0.08", -8}},
+		"no decimal places":   {currencyCode: "JPY", value: "1500", want: Money{"JPY", "1500", 1500}},
+		"three decimals":      {currencyCode: "KWD", value: "1.5", want: Money{"KWD", "1.5", 1500}},
+		"too many decimals":   {currencyCode: "JPY", value: "15.00", err: true},
+		"not a decimal":       {currencyCode: "AUD", value: "abc", err: true},
+		"sign in the decimal": {currencyCode: "AUD", value: "1.-5", err: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseMoney(tt.currencyCode, tt.value)
+			if tt.err {
+				var e ErrMoneyInvalidValue
+				if !errors.As(err, &e) {
+					t.Errorf("ParseMoney() returned an unexpected error;\nerror=%v\n", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("ParseMoney() returned an error;\nerror=%v\n", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney() returned unexpected value;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_MoneyValidate(t *testing.T) {
+	tests := map[string]struct {
+		money Money
+		err   bool
+	}{
+		"valid":                  {money: Money{"IDR", "-1053698.77", -105369877}},
+		"valid without decimals": {money: Money{"JPY", "-1500", -1500}},
+		"zero money":             {money: Money{}},
+		"mismatched base units":  {money: Money{"AUD", "1422.00", 1422}, err: true},
+		"unknown currency":       {money: Money{"XXX", "1.00", 100}, err: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.money.Validate()
+			if tt.err != (err != nil) {
+				t.Errorf("Validate() returned an unexpected error;\nerror=%v\n", err)
+			}
+		})
+	}
+}
+
+func Test_LookupCurrency(t *testing.T) {
+	tests := map[string]struct {
+		code string
+		want Currency
+		ok   bool
+	}{
+		"aud":     {code: "AUD", want: Currency{"AUD", 2, "$", "Australian Dollar"}, ok: true},
+		"jpy":     {code: "JPY", want: Currency{"JPY", 0, "¥", "Yen"}, ok: true},
+		"unknown": {code: "XXX"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := LookupCurrency(tt.code)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("LookupCurrency() returned unexpected value;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
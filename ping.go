@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
 )
 
@@ -25,7 +24,7 @@ type PingMeta struct {
 // https://developer.up.com.au/#get_util_ping.
 func (c *Client) Ping(ctx context.Context) (*Ping, error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "Ping")
+	newCtx, span := c.startOperation(ctx, "Ping")
 	defer span.End()
 
 	var p *Ping
@@ -0,0 +1,82 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Do sends a request to any endpoint of the API, for endpoints this package
+// doesn't cover yet. The path is relative to the API endpoint (eg.
+// "/webhooks"), though a full URL returned by the API (eg. from Links) is also
+// accepted. The body, if not nil, is encoded as JSON, and the response is
+// decoded into out, if not nil. The request is sent the same way as any other
+// in this package, so shares its headers, tracing, logging, and error
+// handling.
+func (c *Client) Do(
+	ctx context.Context,
+	method string,
+	path string,
+	query url.Values,
+	body interface{},
+	out interface{},
+) error {
+
+	newCtx, span := c.startOperation(ctx, "Do")
+	defer span.End()
+
+	if _, err := c.sender(newCtx, senderRequest{
+		method:  method,
+		path:    strings.TrimPrefix(path, c.endpoint),
+		body:    body,
+		queries: query,
+	}, out); err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to %s %s: %v", method, path, err))
+		span.RecordError(err)
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// ListRaw lists every page of any paginated endpoint of the API, for
+// endpoints this package doesn't cover yet, decoding each item in "data" into
+// T. See Client.Do for how path is handled.
+func ListRaw[T any](
+	ctx context.Context,
+	c *Client,
+	path string,
+	query url.Values,
+) (items []T, err error) {
+
+	newCtx, span := c.startOperation(ctx, "ListRaw")
+	defer span.End()
+
+	sr := senderRequest{
+		method:  http.MethodGet,
+		path:    strings.TrimPrefix(path, c.endpoint),
+		queries: query,
+	}
+
+	span.SetAttributes(queryAttributes(sr.queries)...)
+	for page := 1; ; page++ {
+		sr.page = page
+		var resp WrapperSlice[T]
+		if _, err := c.sender(newCtx, sr, &resp); err != nil {
+			span.SetStatus(codes.Error, fmt.Sprintf("failed to list %s: %v", path, err))
+			span.RecordError(err)
+			return nil, fmt.Errorf("listing %s: %w", path, err)
+		}
+		items = append(items, resp.Data...)
+		if resp.Links.Next == "" {
+			c.recordList(newCtx, page, len(items))
+			break
+		}
+		sr.path = strings.Replace(resp.Links.Next, c.endpoint, "", 1)
+		sr.queries = nil
+	}
+	return items, nil
+}
@@ -0,0 +1,140 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Do(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		path   string
+		query  url.Values
+		body   interface{}
+		want   string // The request the mock should receive.
+		err    string
+	}{
+		"get with query": {
+			method: http.MethodGet,
+			path:   "/webhooks",
+			query:  url.Values{"page[size]": {"5"}},
+			want:   "GET /api/v1/webhooks?page%5Bsize%5D=5 ",
+		},
+		"post with body": {
+			method: http.MethodPost,
+			path:   "/webhooks",
+			body:   map[string]string{"url": "https://example.com"},
+			want:   `POST /api/v1/webhooks {"url":"https://example.com"}`,
+		},
+		"full url": {
+			method: http.MethodDelete,
+			path:   "https://api.up.com.au/api/v1/webhooks/1",
+			want:   "DELETE /api/v1/webhooks/1 ",
+		},
+		"error response": {
+			method: http.MethodGet,
+			path:   "/missing",
+			err:    "status_code=404",
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		var got string
+		c := newTestClient(t, &mockRoundTripper{
+			MockFunc: func(req *http.Request) *http.Response {
+				if req.URL.Path == "/api/v1/missing" {
+					return &http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"404","title":"Not Found"}]}`)),
+						Header:     make(http.Header),
+					}
+				}
+				var body []byte
+				if req.Body != nil {
+					body, _ = io.ReadAll(req.Body)
+				}
+				got = fmt.Sprintf("%s %s %s", req.Method, req.URL.RequestURI(), body)
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"data":{"id":"1"}}`)),
+					Header:     make(http.Header),
+				}
+			},
+		})
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			var out Wrapper[Object]
+			err := c.Do(ctx, tt.method, tt.path, tt.query, tt.body, &out)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Do() returned an unexpected error;\nwant=%v\ngot=%v\n", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Do() returned an error;\nerror=%v\n", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Do() sent an unexpected request;\nwant=%v\ngot=%v\n", tt.want, got)
+			}
+			if out.Data.ID != "1" {
+				t.Errorf("Do() didn't decode the response;\ngot=%+v\n", out)
+			}
+		})
+	}
+}
+
+func Test_ListRaw(t *testing.T) {
+
+	// tracing context.
+	ctx := context.Background()
+
+	// setup client.
+	c := newTestClient(t, &mockRoundTripper{
+		MockFunc: func(req *http.Request) *http.Response {
+			b := tagsTestdata[0].content
+			for i := 0; i < len(tagsTestdata); i++ {
+				if strings.Contains(req.URL.String(), fmt.Sprintf("---%v", i+1)) {
+					b = tagsTestdata[i].content
+					break
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer(b)),
+				Header:     make(http.Header),
+			}
+		},
+	})
+
+	got, err := ListRaw[json.RawMessage](ctx, c, "/tags", nil)
+	if err != nil {
+		t.Fatalf("ListRaw() returned an error;\nerror=%v\n", err)
+	}
+	var ids []string
+	for _, raw := range got {
+		var o Object
+		if err := json.Unmarshal(raw, &o); err != nil {
+			t.Fatalf("ListRaw() returned invalid JSON;\nerror=%v\n", err)
+		}
+		ids = append(ids, o.ID)
+	}
+	want := []string{"Holiday", "Pizza Night", "Dining Out", "Shopping", "Fitness"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("ListRaw() returned unexpected items;\nwant=%v\ngot=%v\n", want, ids)
+	}
+}
@@ -0,0 +1,268 @@
+package up
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// RecorderMode defines whether a Recorder records or replays responses.
+type RecorderMode string
+
+const (
+	RecorderModeRecord RecorderMode = "RECORD" // Requests are sent, and the responses recorded to the cassette.
+	RecorderModeReplay RecorderMode = "REPLAY" // Responses are replayed from the cassette; unmatched requests fail.
+)
+
+// CassetteRequest is a request saved in a cassette.
+type CassetteRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Header http.Header     `json:"header,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// CassetteResponse is a response saved in a cassette.
+type CassetteResponse struct {
+	StatusCode int             `json:"statusCode"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// CassetteInteraction is a request, and the response it received, saved in a
+// cassette.
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// Cassette holds the interactions recorded by a Recorder.
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// RecorderOptionTransport sets the transport requests are sent with when
+// recording. Defaults to http.DefaultTransport.
+func RecorderOptionTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// RecorderOptionAnonymiser sets the Anonymiser interactions are scrubbed with
+// before they're recorded, and replayed requests are matched with. Defaults to
+// NewAnonymiser().
+func RecorderOptionAnonymiser(a *Anonymiser) RecorderOption {
+	return func(r *Recorder) {
+		r.anonymiser = a
+	}
+}
+
+// RecorderOptionScrub adds a function that scrubs each interaction before it's
+// recorded, after the default scrubbing (eg. to remove details specific to a
+// test account).
+func RecorderOptionScrub(scrub func(*CassetteInteraction)) RecorderOption {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, scrub)
+	}
+}
+
+// Recorder is an http.RoundTripper that records requests to the API, and the
+// responses received, to a cassette file, and replays them from it, for
+// deterministic tests. Use it with WithHttpClient:
+//
+//	r, err := up.NewRecorder("testdata/cassettes/list-tags.json", up.RecorderModeReplay)
+//	c, err := up.New(ctx, token, up.WithHttpClient(&http.Client{Transport: r}))
+//
+// Interactions are scrubbed before being recorded: headers that identify the
+// caller, or change each run (eg. Authorization, User-Agent and traceparent),
+// are dropped, and URLs and bodies are anonymised with an Anonymiser, so IDs,
+// links, free text, dates and amounts don't identify anyone. Replayed
+// requests match an interaction by either their original, or anonymised, URL,
+// so a test can use IDs from replayed responses, or the originals.
+type Recorder struct {
+	path       string
+	mode       RecorderMode
+	transport  http.RoundTripper
+	anonymiser *Anonymiser
+	scrubbers  []func(*CassetteInteraction)
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool // Whether each interaction has been replayed.
+}
+
+// NewRecorder returns a Recorder for the cassette at the given path. When
+// replaying, the cassette must already exist; when recording, call Save once
+// done to write the cassette.
+func NewRecorder(path string, mode RecorderMode, options ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:       path,
+		mode:       mode,
+		transport:  http.DefaultTransport,
+		anonymiser: NewAnonymiser(),
+	}
+	for _, o := range options {
+		o(r)
+	}
+	switch mode {
+	case RecorderModeRecord:
+	case RecorderModeReplay:
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, ErrRecorderFailedLoad{path, err}
+		}
+		if err := json.Unmarshal(b, &r.cassette); err != nil {
+			return nil, ErrRecorderFailedLoad{path, err}
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, ErrRecorderInvalidMode{mode}
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == RecorderModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+// record sends the request and records it, and the response, in the
+// cassette.
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	// scrub the interaction before it's recorded.
+	i := CassetteInteraction{
+		Request: CassetteRequest{
+			Method: req.Method,
+			URL:    r.anonymiser.link(req.URL.String()),
+			Header: req.Header.Clone(),
+			Body:   r.scrubBody(reqBody),
+		},
+		Response: CassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       r.scrubBody(respBody),
+		},
+	}
+	for _, h := range []string{"Authorization", "Cookie", "User-Agent", "Traceparent", "Tracestate", "Content-Length"} {
+		i.Request.Header.Del(h)
+	}
+	for _, h := range []string{"Set-Cookie", "Content-Length"} {
+		i.Response.Header.Del(h)
+	}
+	for _, scrub := range r.scrubbers {
+		scrub(&i)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay returns the recorded response for the first unused interaction
+// matching the request, or the last matching interaction if they've all been
+// used.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	anonymised, err := url.Parse(r.anonymiser.link(req.URL.String()))
+	if err != nil {
+		anonymised = req.URL
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	match := -1
+	for n, i := range r.cassette.Interactions {
+		u, err := url.Parse(i.Request.URL)
+		if err != nil || req.Method != i.Request.Method || !(urlsMatch(req.URL, u) || urlsMatch(anonymised, u)) {
+			continue
+		}
+		match = n
+		if !r.used[n] {
+			break
+		}
+	}
+	if match == -1 {
+		return nil, ErrRecorderNoMatch{req.Method, req.URL.RequestURI(), r.path}
+	}
+	r.used[match] = true
+
+	resp := r.cassette.Interactions[match].Response
+	header := resp.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	header.Set("Content-Length", strconv.Itoa(len(resp.Body)))
+	return &http.Response{
+		Status:        http.StatusText(resp.StatusCode),
+		StatusCode:    resp.StatusCode,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// urlsMatch reports whether the given URLs have the same path and normalised
+// query.
+func urlsMatch(a, b *url.URL) bool {
+	return a.Path == b.Path && a.Query().Encode() == b.Query().Encode()
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return ErrFailedMarshal{err}
+	}
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil {
+		return ErrRecorderFailedSave{r.path, err}
+	}
+	return nil
+}
+
+// scrubBody returns the given JSON body anonymised, so it can be recorded.
+// Bodies that aren't JSON are dropped.
+func (r *Recorder) scrubBody(b []byte) json.RawMessage {
+	if len(b) == 0 {
+		return nil
+	}
+	scrubbed, err := r.anonymiser.Anonymise(b)
+	if err != nil {
+		return nil
+	}
+	return scrubbed
+}
@@ -0,0 +1,46 @@
+package up
+
+import "fmt"
+
+// ErrRecorderInvalidMode is returned when a Recorder is given an unknown
+// RecorderMode.
+type ErrRecorderInvalidMode struct {
+	mode RecorderMode
+}
+
+func (e ErrRecorderInvalidMode) Error() string {
+	return fmt.Sprintf("invalid recorder mode %q", e.mode)
+}
+
+// ErrRecorderFailedLoad is returned when a Recorder fails to load a cassette
+// to replay.
+type ErrRecorderFailedLoad struct {
+	path string
+	err  error
+}
+
+func (e ErrRecorderFailedLoad) Error() string {
+	return fmt.Sprintf("failed to load cassette %s: %v", e.path, e.err)
+}
+
+// ErrRecorderFailedSave is returned when a Recorder fails to save a cassette.
+type ErrRecorderFailedSave struct {
+	path string
+	err  error
+}
+
+func (e ErrRecorderFailedSave) Error() string {
+	return fmt.Sprintf("failed to save cassette %s: %v", e.path, e.err)
+}
+
+// ErrRecorderNoMatch is returned when a Recorder is replaying, and a request
+// doesn't match any recorded in the cassette.
+type ErrRecorderNoMatch struct {
+	Method string // The method of the unmatched request.
+	URI    string // The path and query of the unmatched request.
+	path   string
+}
+
+func (e ErrRecorderNoMatch) Error() string {
+	return fmt.Sprintf("no request matching %s %s was recorded in cassette %s", e.Method, e.URI, e.path)
+}
@@ -0,0 +1,142 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Recorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	// record.
+	live := &mockRoundTripper{
+		MockFunc: func(req *http.Request) *http.Response {
+			b := transactionTestdata.content
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/util/ping"):
+				b = pingTestdata.content
+			case strings.HasSuffix(req.URL.Path, "/tags"):
+				b = tagsTestdata[0].content
+				for i := 0; i < len(tagsTestdata); i++ {
+					if strings.Contains(req.URL.String(), fmt.Sprintf("---%v", i+1)) {
+						b = tagsTestdata[i].content
+						break
+					}
+				}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer(b)),
+				Header:     make(http.Header),
+			}
+		},
+	}
+	recorder, err := NewRecorder(path, RecorderModeRecord, RecorderOptionTransport(live))
+	if err != nil {
+		t.Fatalf("NewRecorder() returned an error; error=%v", err)
+	}
+	c, err := New(context.Background(), "xxxx", WithHttpClient(&http.Client{Transport: recorder}), WithUserAgent("recorder-test/1.0"))
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+	wantTags, err := c.ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("ListTags() returned an error; error=%v", err)
+	}
+	if _, err := c.GetTransaction(context.Background(), "6fff09f5-be7d-4ae1-9f71-4a25440bc405"); err != nil {
+		t.Fatalf("GetTransaction() returned an error; error=%v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save() returned an error; error=%v", err)
+	}
+
+	// the cassette is scrubbed.
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read cassette; error=%v", err)
+	}
+	for _, u := range []string{"Bearer", "xxxx", "Warung Bebek Bengil", "WARUNG BEBEK", "6fff09f5-be7d-4ae1-9f71-4a25440bc405", "Pizza Night", "-107.92", "recorder-test", "Traceparent", "Content-Length"} {
+		if bytes.Contains(b, []byte(u)) {
+			t.Errorf("cassette contains %q", u)
+		}
+	}
+
+	// tags are replayed anonymised, consistently with their links.
+	a := NewAnonymiser()
+	for i := range wantTags {
+		wantTags[i].ID = a.id("tags", wantTags[i].ID)
+		wantTags[i].Relationships.Transactions.Links.Related = a.link(wantTags[i].Relationships.Transactions.Links.Related)
+	}
+
+	// replay.
+	tests := map[string]struct {
+		run func(c *Client) (interface{}, error)
+		err string
+	}{
+		"list tags": {
+			run: func(c *Client) (interface{}, error) {
+				return c.ListTags(context.Background())
+			},
+		},
+		"get transaction": {
+			run: func(c *Client) (interface{}, error) {
+				return c.GetTransaction(context.Background(), "6fff09f5-be7d-4ae1-9f71-4a25440bc405")
+			},
+		},
+		"get transaction by anonymised id": {
+			run: func(c *Client) (interface{}, error) {
+				return c.GetTransaction(context.Background(), NewAnonymiser().id("transactions", "6fff09f5-be7d-4ae1-9f71-4a25440bc405"))
+			},
+		},
+		"query encoding is normalised": {
+			run: func(c *Client) (interface{}, error) {
+				var resp TagsPaginationWrapper
+				return resp, c.Do(context.Background(), http.MethodGet, "/tags?page%5Bsize%5D=100", nil, nil, &resp)
+			},
+		},
+		"unmatched request": {
+			run: func(c *Client) (interface{}, error) {
+				return c.GetTransaction(context.Background(), "missing")
+			},
+			err: "no request matching GET /api/v1/transactions/missing was recorded",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			replayer, err := NewRecorder(path, RecorderModeReplay)
+			if err != nil {
+				t.Fatalf("NewRecorder() returned an error; error=%v", err)
+			}
+			c, err := New(context.Background(), "yyyy", WithHttpClient(&http.Client{Transport: replayer}))
+			if err != nil {
+				t.Fatalf("New() returned an error; error=%v", err)
+			}
+			got, err := tt.run(c)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("replay returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("replay returned an error; error=%v", err)
+			}
+			if tags, ok := got.([]TagResource); ok && !reflect.DeepEqual(tags, wantTags) {
+				t.Errorf("replay returned unexpected tags;\nwant=%+v\ngot=%+v\n", wantTags, tags)
+			}
+		})
+	}
+
+	// a missing cassette can't be replayed.
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecorderModeReplay); err == nil {
+		t.Errorf("NewRecorder() didn't return an error for a missing cassette")
+	}
+}
@@ -0,0 +1,116 @@
+package up
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitStatus is the state of the API's rate limit, as reported by the
+// headers of a response. Fields are zero when the matching header wasn't sent.
+type RateLimitStatus struct {
+	Limit      int           // The number of requests allowed in the current window.
+	Remaining  int           // The number of requests left in the current window.
+	Reset      time.Time     // When the current window resets.
+	RetryAfter time.Duration // How long to wait before retrying, after a 429.
+	UpdatedAt  time.Time     // When the response these came from was received; zero if never.
+}
+
+// parseRateLimitStatus returns the rate limit status reported by the given
+// response, and whether it reported any.
+func parseRateLimitStatus(resp *http.Response, now time.Time) (s RateLimitStatus, ok bool) {
+	h := resp.Header
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Limit")); err == nil {
+		s.Limit, ok = v, true
+	}
+	if v, err := strconv.Atoi(h.Get("X-RateLimit-Remaining")); err == nil {
+		s.Remaining, ok = v, true
+	}
+	if v, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		// either a unix timestamp, or a number of seconds from now.
+		if v > 1e9 {
+			s.Reset = time.Unix(v, 0)
+		} else {
+			s.Reset = now.Add(time.Duration(v) * time.Second)
+		}
+		ok = true
+	}
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			s.RetryAfter, ok = time.Duration(secs)*time.Second, true
+		} else if t, err := http.ParseTime(v); err == nil {
+			s.RetryAfter, ok = t.Sub(now), true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		ok = true
+	}
+	s.UpdatedAt = now
+	return s, ok
+}
+
+// rateLimitState holds the latest rate limit status seen by a client.
+type rateLimitState struct {
+	mu     sync.RWMutex
+	status RateLimitStatus
+}
+
+// RateLimitStatus returns the rate limit status reported by the latest
+// response from the API that included one. Schedulers can use this to back off
+// before the API starts returning 429s.
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	c.rateLimit.mu.RLock()
+	defer c.rateLimit.mu.RUnlock()
+	return c.rateLimit.status
+}
+
+// ResponseMeta holds metadata about the responses received from the API
+// during a call. Attach one to a context with ContextWithResponseMeta before
+// making the call, and read it once the call returns.
+type ResponseMeta struct {
+	mu sync.Mutex
+
+	StatusCode    int             // The status code of the last response.
+	RequestID     string          // The request ID of the last response, if sent by the API.
+	CorrelationID string          // The correlation ID of the last response, if sent by the API.
+	Header        http.Header     // The headers of the last response.
+	RateLimit     RateLimitStatus // The rate limit status reported by the last response.
+	Pages         int             // The number of responses received (eg. pages fetched by a List call).
+}
+
+// record updates the metadata from the given response.
+func (m *ResponseMeta) record(resp *http.Response, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.StatusCode = resp.StatusCode
+	m.RequestID = resp.Header.Get("X-Request-Id")
+	m.CorrelationID = resp.Header.Get("X-Correlation-Id")
+	m.Header = resp.Header.Clone()
+	m.RateLimit, _ = parseRateLimitStatus(resp, now)
+	m.Pages++
+}
+
+// responseMetaKey is the context key for a *ResponseMeta.
+type responseMetaKey struct{}
+
+// ContextWithResponseMeta returns a copy of ctx that records metadata about
+// every response received while making a call with it into meta.
+func ContextWithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaKey{}, meta)
+}
+
+// recordResponse updates the client's rate limit status, and any
+// ResponseMeta in the context, from the given response.
+func (c *Client) recordResponse(ctx context.Context, resp *http.Response) {
+	now := time.Now()
+	if s, ok := parseRateLimitStatus(resp, now); ok {
+		c.rateLimit.mu.Lock()
+		c.rateLimit.status = s
+		c.rateLimit.mu.Unlock()
+	}
+	if meta, ok := ctx.Value(responseMetaKey{}).(*ResponseMeta); ok && meta != nil {
+		meta.record(resp, now)
+	}
+}
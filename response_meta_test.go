@@ -0,0 +1,104 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_ResponseMeta(t *testing.T) {
+
+	// tracing context.
+	meta := &ResponseMeta{}
+	ctx := ContextWithResponseMeta(context.Background(), meta)
+
+	// setup client.
+	var remaining int
+	c := newTestClient(t, &mockRoundTripper{
+		MockFunc: func(req *http.Request) *http.Response {
+			b := tagsTestdata[0].content
+			for i := 0; i < len(tagsTestdata); i++ {
+				if strings.Contains(req.URL.String(), fmt.Sprintf("---%v", i+1)) {
+					b = tagsTestdata[i].content
+					break
+				}
+			}
+			remaining--
+			h := make(http.Header)
+			h.Set("X-Request-Id", fmt.Sprintf("req-%d", remaining))
+			h.Set("X-RateLimit-Limit", "100")
+			h.Set("X-RateLimit-Remaining", fmt.Sprint(100+remaining))
+			h.Set("X-RateLimit-Reset", "1735689600")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer(b)),
+				Header:     h,
+			}
+		},
+	})
+	if got := c.RateLimitStatus(); !got.UpdatedAt.IsZero() {
+		t.Errorf("RateLimitStatus() returned a status before any were reported;\ngot=%+v\n", got)
+	}
+
+	// run tests.
+	if _, err := c.ListTags(ctx); err != nil {
+		t.Fatalf("ListTags() returned an error;\nerror=%v\n", err)
+	}
+	if meta.Pages != 3 || meta.StatusCode != http.StatusOK || meta.RequestID != "req--3" {
+		t.Errorf("ListTags() recorded unexpected metadata;\ngot=%+v\n", meta)
+	}
+	want := RateLimitStatus{Limit: 100, Remaining: 97, Reset: time.Unix(1735689600, 0)}
+	got := c.RateLimitStatus()
+	if got.Limit != want.Limit ||
+		got.Remaining != want.Remaining ||
+		!got.Reset.Equal(want.Reset) ||
+		got.UpdatedAt.IsZero() {
+		t.Errorf("RateLimitStatus() returned unexpected status;\nwant=%+v\ngot=%+v\n", want, got)
+	}
+}
+
+func Test_parseRateLimitStatus(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		code   int
+		header http.Header
+		want   RateLimitStatus
+		ok     bool
+	}{
+		"no headers": {
+			code: http.StatusOK,
+		},
+		"relative reset": {
+			code:   http.StatusOK,
+			header: http.Header{"X-Ratelimit-Remaining": {"5"}, "X-Ratelimit-Reset": {"30"}},
+			want:   RateLimitStatus{Remaining: 5, Reset: now.Add(30 * time.Second)},
+			ok:     true,
+		},
+		"too many requests": {
+			code:   http.StatusTooManyRequests,
+			header: http.Header{"Retry-After": {"10"}},
+			want:   RateLimitStatus{RetryAfter: 10 * time.Second},
+			ok:     true,
+		},
+		"retry after date": {
+			code:   http.StatusTooManyRequests,
+			header: http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}},
+			want:   RateLimitStatus{RetryAfter: time.Minute},
+			ok:     true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseRateLimitStatus(&http.Response{StatusCode: tt.code, Header: tt.header}, now)
+			tt.want.UpdatedAt = now
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("parseRateLimitStatus() returned unexpected status;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+		})
+	}
+}
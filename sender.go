@@ -7,8 +7,14 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"reflect"
+	"time"
 
-	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // senderRequest represents the parameters for sending a request to the API,
@@ -18,6 +24,7 @@ type senderRequest struct {
 	path    string      // The path appended to the API endpoint to send request to.
 	body    interface{} // The request body.
 	queries url.Values  // Any URL query parameters to send with the request.
+	page    int         // The page being fetched, when listing; zero otherwise.
 }
 
 // apiErrorResponseErrorSource represents the source of an API error returned
@@ -40,6 +47,53 @@ type apiErrorResponse struct {
 	Errors []apiErrorResponseError `json:"errors"`
 }
 
+// checkDecoded returns the issues found in a response body decoded into
+// result, according to the client's strict decoding mode.
+func (c *Client) checkDecoded(b []byte, result interface{}) []DecodeIssue {
+	switch c.decodingMode {
+	case StrictDecodingModeWarn, StrictDecodingModeError:
+	default:
+		return nil
+	}
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil
+	}
+	issues := findUnknownFields(raw, reflect.TypeOf(result), "")
+	return append(issues, findDecodeIssues(result)...)
+}
+
+// newRequest returns a *http.Request for the given senderRequest, with the
+// given marshalled body and token, carrying the trace in the given context.
+func (c *Client) newRequest(
+	ctx context.Context,
+	sr senderRequest,
+	body []byte,
+	token string,
+) (*http.Request, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, sr.method, c.endpoint+sr.path, bodyReader)
+	if err != nil {
+		return nil, ErrSenderFailedSetupRequest{err}
+	}
+	if sr.queries != nil {
+		req.URL.RawQuery = sr.queries.Encode()
+	}
+
+	// clone the shared headers map — c.headers is read-only after init but
+	// net/http may write to the Header map during Do(), causing a data race
+	// when multiple goroutines call sender concurrently.
+	req.Header = c.headers.Clone()
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// propagate the trace, so proxies and stand-ins for the API can join it.
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, nil
+}
+
 // sender sends a HTTP request, configured by the senderRequest, to the API and
 // processes the response. A 'result' interface{} can be given to unmarshal any
 // body returned in the response, which then can be used wherever this function
@@ -51,58 +105,118 @@ func (c *Client) sender(
 ) (resp *http.Response, err error) {
 
 	// setup tracing.
-	_, span := otel.Tracer(c.tracerName).Start(ctx, "sender")
+	ctx, span := c.tracer().Start(ctx, "sender",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPRequestMethodKey.String(sr.method)),
+	)
 	defer span.End()
+	if sr.page > 0 {
+		span.SetAttributes(attribute.Int("up.page", sr.page))
+	}
+	logger := c.loggerFor(ctx)
 
 	// setup request.
-	var bodyReader io.Reader
+	var body []byte
 	if !isNil(sr.body) {
-		b, err := json.Marshal(sr.body)
-		if err != nil {
+		if body, err = json.Marshal(sr.body); err != nil {
 			return nil, ErrFailedMarshal{err}
 		}
-		bodyReader = bytes.NewReader(b)
 	}
 
-	req, err := http.NewRequest(sr.method, c.endpoint+sr.path, bodyReader)
+	// send request; if the token is rejected, refresh it and retry once, so a
+	// rotated token doesn't need a new client.
+	token, err := c.tokenProvider.Token(ctx)
 	if err != nil {
-		return nil, ErrSenderFailedSetupRequest{err}
-	}
-	if sr.queries != nil {
-		req.URL.RawQuery = sr.queries.Encode()
+		return nil, ErrSenderFailedGetToken{err}
 	}
-
-	// clone the shared headers map — c.headers is read-only after init but
-	// net/http may write to the Header map during Do(), causing a data race
-	// when multiple goroutines call sender concurrently.
-	req.Header = c.headers.Clone()
-
-	// send request.
-	resp, err = c.httpClient.Do(req)
-	if err != nil {
-		return nil, ErrSenderFailedSendRequest{err}
+	var start time.Time
+	for attempt := 0; ; attempt++ {
+		req, err := c.newRequest(ctx, sr, body, token)
+		if err != nil {
+			return nil, err
+		}
+		span.SetAttributes(
+			semconv.URLFull(req.URL.String()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		)
+		start = time.Now()
+		resp, err = c.httpClient.Do(req)
+		if err != nil {
+			c.metrics.recordRequest(ctx, sr.method, 0, time.Since(start))
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, ErrSenderFailedSendRequest{err}
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			break
+		}
+		refreshed, err := c.tokenProvider.Refresh(ctx, token)
+		if err != nil || refreshed == token {
+			// nothing to retry with; report the original response.
+			logger.Debug("failed to refresh rejected token", "error", err)
+			break
+		}
+		span.AddEvent("token refreshed")
+		logger.Info("token rejected by API; retrying with refreshed token")
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		c.metrics.recordRequest(ctx, sr.method, resp.StatusCode, time.Since(start))
+		c.metrics.recordRetry(ctx, retryReasonTokenRefreshed)
+		token = refreshed
 	}
 	defer resp.Body.Close()
+	c.recordResponse(ctx, resp)
 
-	// parse response.
+	// parse response; the request took until the response was read.
 	b, err := io.ReadAll(resp.Body)
+	c.metrics.recordRequest(ctx, sr.method, resp.StatusCode, time.Since(start))
 	if err != nil {
 		return nil, ErrSenderFailedParseResponse{err}
 	}
 
 	// determine if the response was successful or a failure.
 	if http.StatusOK <= resp.StatusCode && resp.StatusCode < http.StatusMultipleChoices {
-		c.logger.Debug("response from API", "code", resp.StatusCode, "body", string(b))
-		if len(b) > 0 {
-			return resp, json.Unmarshal(b, &result)
+		logger.Debug("response from API", "code", resp.StatusCode, "body", c.bodyLogValue(b))
+		if len(b) == 0 {
+			return resp, nil
+		}
+		if err := json.Unmarshal(b, &result); err != nil {
+			return resp, err
+		}
+		if issues := c.checkDecoded(b, result); len(issues) > 0 {
+			for _, i := range issues {
+				span.AddEvent("decode issue", trace.WithAttributes(
+					attribute.String("kind", string(i.Kind)),
+					attribute.String("path", i.Path),
+					attribute.String("value", i.Value),
+				))
+			}
+
+			// unknown enum values are only warned about, even when strict, as
+			// Up adds them without notice and doesn't document them all.
+			var strict, warn []DecodeIssue
+			for _, i := range issues {
+				if c.decodingMode == StrictDecodingModeError && i.Kind != DecodeIssueKindUnknownEnumValue {
+					strict = append(strict, i)
+					continue
+				}
+				warn = append(warn, i)
+			}
+			if len(strict) > 0 {
+				return resp, ErrStrictDecoding{strict}
+			}
+			logger.Warn("response doesn't match schema", "path", sr.path, "issues", warn)
 		}
 		return resp, nil
 	}
 
-	c.logger.Error("response from API", "code", resp.StatusCode, "body", string(b))
+	logger.Error("response from API", "code", resp.StatusCode, "body", c.bodyLogValue(b))
 	var errs apiErrorResponse
 	if err := json.Unmarshal(b, &errs); err != nil {
 		return nil, ErrFailedUnmarshal{err}
 	}
+	c.metrics.recordErrors(ctx, resp.StatusCode, errs)
+	span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
 	return nil, ErrSenderInvalidResponse{errs, resp.StatusCode}
 }
@@ -15,6 +15,16 @@ func (e ErrSenderFailedSetupRequest) Error() string {
 	return fmt.Sprintf("failed to setup http request: %v", e.err)
 }
 
+// ErrSenderFailedGetToken is returned whenever the sender fails to get a
+// token from the client's TokenProvider.
+type ErrSenderFailedGetToken struct {
+	err error
+}
+
+func (e ErrSenderFailedGetToken) Error() string {
+	return fmt.Sprintf("failed to get token: %v", e.err)
+}
+
 // ErrSenderFailedSendRequest is returned whenever the sender fails to send
 // a new *http.Request to the API.
 type ErrSenderFailedSendRequest struct {
@@ -54,3 +64,8 @@ func (e ErrSenderInvalidResponse) Error() string {
 		strings.Join(errs, ";"),
 	)
 }
+
+// StatusCode returns the HTTP status code of the error response.
+func (e ErrSenderInvalidResponse) StatusCode() int {
+	return e.statusCode
+}
@@ -1,6 +1,12 @@
 package up
 
+// TagRelationships defines the relationships to other resources for a tag.
+type TagRelationships struct {
+	Transactions WrapperOmittable `json:"transactions"`
+}
+
 // TagResource defines the core details of a tag.
 type TagResource struct {
 	Object
+	Relationships TagRelationships `json:"relationships,omitzero"` // Omitted when tagging transactions.
 }
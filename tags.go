@@ -7,10 +7,14 @@ import (
 	"strconv"
 	"strings"
 
-	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 )
 
+// MaxTagsPerTransaction is the maximum number of tags Up allows on a single
+// transaction.
+const MaxTagsPerTransaction = 6
+
 // TagsPaginationWrapper is a pagination wrapper for a slice of TagResource.
 type TagsPaginationWrapper WrapperSlice[TagResource]
 
@@ -41,7 +45,7 @@ func (c *Client) ListTags(
 	opts ...ListTagsOption,
 ) (tags []TagResource, err error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "ListTags")
+	newCtx, span := c.startOperation(ctx, "ListTags")
 	defer span.End()
 
 	sr := senderRequest{
@@ -50,7 +54,9 @@ func (c *Client) ListTags(
 		queries: setupQueries(opts),
 	}
 
-	for {
+	span.SetAttributes(queryAttributes(sr.queries)...)
+	for page := 1; ; page++ {
+		sr.page = page
 		var resp TagsPaginationWrapper
 		if _, err := c.sender(newCtx, sr, &resp); err != nil {
 			span.SetStatus(codes.Error, fmt.Sprintf("failed to list tags: %v", err))
@@ -59,6 +65,7 @@ func (c *Client) ListTags(
 		}
 		tags = append(tags, resp.Data...)
 		if resp.Links.Next == "" {
+			c.recordList(newCtx, page, len(tags))
 			break
 		}
 		sr.path = strings.Replace(resp.Links.Next, c.endpoint, "", 1)
@@ -73,7 +80,10 @@ func (c *Client) ListTags(
 // https://developer.up.com.au/#post_transactions_transactionId_relationships_tags.
 func (c *Client) AddTagsToTransaction(ctx context.Context, id string, tags []string) error {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "AddTagsToTransaction")
+	newCtx, span := c.startOperation(ctx, "AddTagsToTransaction",
+		resourceIDAttribute(id),
+		attribute.StringSlice("up.tags", tags),
+	)
 	defer span.End()
 
 	_, err := c.sender(newCtx, senderRequest{
@@ -94,7 +104,10 @@ func (c *Client) AddTagsToTransaction(ctx context.Context, id string, tags []str
 // https://developer.up.com.au/#delete_transactions_transactionId_relationships_tags.
 func (c *Client) RemoveTagsFromTransaction(ctx context.Context, id string, tags []string) error {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "RemoveTagsFromTransaction")
+	newCtx, span := c.startOperation(ctx, "RemoveTagsFromTransaction",
+		resourceIDAttribute(id),
+		attribute.StringSlice("up.tags", tags),
+	)
 	defer span.End()
 
 	_, err := c.sender(newCtx, senderRequest{
@@ -0,0 +1,265 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// BulkTagRequest describes the tags to add to, and remove from, a set of
+// transactions.
+type BulkTagRequest struct {
+	Add            []string // Tags to add to each transaction.
+	Remove         []string // Tags to remove from each transaction.
+	TransactionIDs []string // The transactions to update.
+}
+
+// BulkTagOption configures a BulkTag, RenameTag or MergeTags call.
+type BulkTagOption func(*bulkTagConfig)
+
+// bulkTagConfig holds the configuration for a BulkTag, RenameTag or MergeTags
+// call.
+type bulkTagConfig struct {
+	workers  int           // The maximum number of transactions updated at once.
+	interval time.Duration // The minimum time between requests to the API.
+	dryRun   bool          // Report what would change, without changing anything.
+	resume   []string      // Previously failed transactions to process again.
+}
+
+// newBulkTagConfig returns the default configuration, overwritten with any
+// given options.
+func newBulkTagConfig(options []BulkTagOption) bulkTagConfig {
+	cfg := bulkTagConfig{workers: 5, interval: 100 * time.Millisecond}
+	for _, o := range options {
+		o(&cfg)
+	}
+	return cfg
+}
+
+// BulkTagOptionWorkers sets the maximum number of transactions updated at
+// once. Defaults to 5.
+func BulkTagOptionWorkers(n int) BulkTagOption {
+	return func(c *bulkTagConfig) {
+		c.workers = n
+	}
+}
+
+// BulkTagOptionRateLimit sets the minimum time between requests sent to the
+// API, across all workers. Defaults to 100ms; zero disables rate limiting.
+func BulkTagOptionRateLimit(interval time.Duration) BulkTagOption {
+	return func(c *bulkTagConfig) {
+		c.interval = interval
+	}
+}
+
+// BulkTagOptionDryRun reports what would be changed without changing anything.
+// Transactions that would be updated are reported as succeeded.
+func BulkTagOptionDryRun() BulkTagOption {
+	return func(c *bulkTagConfig) {
+		c.dryRun = true
+	}
+}
+
+// BulkTagOptionResume processes the transactions that failed in a previous
+// report again, alongside any others. This matters for RenameTag and
+// MergeTags, where a transaction that failed part way through may no longer
+// have the source tag, and so wouldn't otherwise be found again.
+func BulkTagOptionResume(previous *BulkTagReport) BulkTagOption {
+	return func(c *bulkTagConfig) {
+		if previous == nil {
+			return
+		}
+		for _, f := range previous.Failed {
+			c.resume = append(c.resume, f.ID)
+		}
+	}
+}
+
+// BulkTagFailure describes why a single transaction couldn't be updated by
+// BulkTag.
+type BulkTagFailure struct {
+	ID     string        // The ID of the transaction.
+	Reason FailureReason // Why the transaction couldn't be updated.
+	Err    error         // The underlying error.
+}
+
+// BulkTagReport holds the outcome of a BulkTag call for every transaction,
+// each in the order they were requested.
+type BulkTagReport struct {
+	DryRun    bool             // Whether nothing was actually changed.
+	Succeeded []string         // Transactions whose tags were (or would be) updated.
+	Skipped   []string         // Transactions that already had the target tags.
+	Failed    []BulkTagFailure // Transactions that couldn't be updated.
+}
+
+// bulkTagOutcome is the outcome of updating a single transaction.
+type bulkTagOutcome struct {
+	skipped bool
+	failure *BulkTagFailure
+}
+
+// BulkTag adds and removes tags across many transactions concurrently. Each
+// transaction is fetched first, through the same rate limit as the changes,
+// so transactions that already have the target tags are skipped, and those that would end up with more than
+// MaxTagsPerTransaction tags are failed without being changed. An error is
+// only returned if the request itself is invalid; per-transaction failures
+// are reported in the BulkTagReport.
+func (c *Client) BulkTag(
+	ctx context.Context,
+	req BulkTagRequest,
+	options ...BulkTagOption,
+) (*BulkTagReport, error) {
+
+	newCtx, span := c.startOperation(ctx, "BulkTag")
+	defer span.End()
+
+	for _, t := range req.Add {
+		if slices.Contains(req.Remove, t) {
+			err := ErrBulkTagConflictingTag{t}
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+	cfg := newBulkTagConfig(options)
+	limiter := &rateLimiter{interval: cfg.interval, metrics: c.metrics}
+
+	// fetch the current state of every transaction; any that can't be fetched
+	// are reported as failures.
+	ids := append(slices.Clone(req.TransactionIDs), cfg.resume...)
+	fetched, err := c.GetTransactions(newCtx, ids,
+		GetTransactionsOptionWorkers(cfg.workers),
+		getTransactionsOptionLimiter(limiter),
+	)
+	var fetchErrs ErrGetTransactions
+	errors.As(err, &fetchErrs)
+
+	report := c.bulkTag(newCtx, cfg, limiter, req, fetched, fetchErrs.Failures)
+	if len(report.Failed) > 0 {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to tag %d transactions", len(report.Failed)))
+	}
+	return report, nil
+}
+
+// bulkTag applies the requested tag changes to the given, already fetched,
+// transactions, through the given rateLimiter, and reports the outcome for
+// each of them.
+func (c *Client) bulkTag(
+	ctx context.Context,
+	cfg bulkTagConfig,
+	limiter *rateLimiter,
+	req BulkTagRequest,
+	fetched *GetTransactionsResult,
+	fetchFailures []GetTransactionsFailure,
+) *BulkTagReport {
+
+	// update each transaction.
+	outcomes := make([]bulkTagOutcome, len(fetched.IDs))
+	forEachConcurrently(len(fetched.IDs), cfg.workers, func(i int) {
+		id := fetched.IDs[i]
+		t, ok := fetched.Transactions[id]
+		if !ok {
+			return
+		}
+		outcomes[i] = c.bulkTagOne(ctx, cfg, limiter, t, req)
+	})
+
+	// build the report.
+	report := &BulkTagReport{DryRun: cfg.dryRun}
+	failures := make(map[string]GetTransactionsFailure, len(fetchFailures))
+	for _, f := range fetchFailures {
+		failures[f.ID] = f
+	}
+	for i, id := range fetched.IDs {
+		o := outcomes[i]
+		switch f, ok := failures[id]; {
+		case ok:
+			report.Failed = append(report.Failed, BulkTagFailure{f.ID, f.Reason, f.Err})
+		case o.failure != nil:
+			report.Failed = append(report.Failed, *o.failure)
+		case o.skipped:
+			report.Skipped = append(report.Skipped, id)
+		default:
+			report.Succeeded = append(report.Succeeded, id)
+		}
+	}
+	return report
+}
+
+// bulkTagOne applies the requested tag changes to a single transaction.
+func (c *Client) bulkTagOne(
+	ctx context.Context,
+	cfg bulkTagConfig,
+	limiter *rateLimiter,
+	t TransactionDataWrapper,
+	req BulkTagRequest,
+) bulkTagOutcome {
+
+	var current []string
+	for _, tag := range t.Relationships.Tags.Data {
+		current = append(current, tag.ID)
+	}
+	var add, remove []string
+	for _, tag := range req.Add {
+		if !slices.Contains(current, tag) && !slices.Contains(add, tag) {
+			add = append(add, tag)
+		}
+	}
+	for _, tag := range req.Remove {
+		if slices.Contains(current, tag) && !slices.Contains(remove, tag) {
+			remove = append(remove, tag)
+		}
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return bulkTagOutcome{skipped: true}
+	}
+	if n := len(current) - len(remove) + len(add); n > MaxTagsPerTransaction {
+		return bulkTagOutcome{failure: &BulkTagFailure{
+			t.ID, FailureReasonPermanent, ErrTooManyTags{t.ID, n},
+		}}
+	}
+	if cfg.dryRun {
+		return bulkTagOutcome{}
+	}
+
+	// add before removing where there's room, so a transaction is never left
+	// with neither the old nor the new tags if the second request fails.
+	type step struct {
+		add bool
+		run func() error
+	}
+	var steps []step
+	if len(add) > 0 {
+		steps = append(steps, step{true, func() error { return c.AddTagsToTransaction(ctx, t.ID, add) }})
+	}
+	if len(remove) > 0 {
+		steps = append(steps, step{false, func() error { return c.RemoveTagsFromTransaction(ctx, t.ID, remove) }})
+	}
+	if len(current)+len(add) > MaxTagsPerTransaction {
+		slices.Reverse(steps)
+	}
+	var removed bool
+	for _, s := range steps {
+		if err := limiter.wait(ctx); err != nil {
+			if removed {
+				return bulkTagOutcome{failure: &BulkTagFailure{t.ID, FailureReasonPartial, ErrBulkTagDropped{t.ID, remove, err}}}
+			}
+			return bulkTagOutcome{failure: &BulkTagFailure{t.ID, FailureReasonTransient, err}}
+		}
+		if err := s.run(); err != nil {
+
+			// the tags were removed to make room, but the new ones weren't
+			// added; the transaction has neither until it's resumed.
+			if removed {
+				return bulkTagOutcome{failure: &BulkTagFailure{t.ID, FailureReasonPartial, ErrBulkTagDropped{t.ID, remove, err}}}
+			}
+			return bulkTagOutcome{failure: &BulkTagFailure{t.ID, failureReasonFor(err), err}}
+		}
+		removed = removed || !s.add
+	}
+	return bulkTagOutcome{}
+}
@@ -0,0 +1,60 @@
+package up
+
+import "fmt"
+
+// ErrTooManyTags is returned when a change would leave a transaction with more
+// than MaxTagsPerTransaction tags.
+type ErrTooManyTags struct {
+	TransactionID string // The ID of the transaction.
+	Count         int    // The number of tags the transaction would have.
+}
+
+func (e ErrTooManyTags) Error() string {
+	return fmt.Sprintf(
+		"transaction %s would have %d tags; the maximum is %d",
+		e.TransactionID,
+		e.Count,
+		MaxTagsPerTransaction,
+	)
+}
+
+// ErrBulkTagConflictingTag is returned when a BulkTagRequest asks for the same
+// tag to be both added and removed.
+type ErrBulkTagConflictingTag struct {
+	Tag string
+}
+
+func (e ErrBulkTagConflictingTag) Error() string {
+	return fmt.Sprintf("tag %q is both added and removed", e.Tag)
+}
+
+// ErrBulkTagDropped is reported when tags were removed from a transaction to
+// make room for the new tags, but the new tags couldn't be added; the
+// transaction has neither until the call is resumed.
+type ErrBulkTagDropped struct {
+	TransactionID string   // The ID of the transaction.
+	Tags          []string // The tags that were removed.
+	err           error
+}
+
+func (e ErrBulkTagDropped) Error() string {
+	return fmt.Sprintf(
+		"removed tags %q from transaction %s, but failed to add the new tags: %v",
+		e.Tags,
+		e.TransactionID,
+		e.err,
+	)
+}
+
+func (e ErrBulkTagDropped) Unwrap() error {
+	return e.err
+}
+
+// ErrMergeTagsEmptyTag is returned when RenameTag or MergeTags is given an
+// empty source or target tag.
+type ErrMergeTagsEmptyTag struct {
+}
+
+func (e ErrMergeTagsEmptyTag) Error() string {
+	return "the source and target tags must not be empty"
+}
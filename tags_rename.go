@@ -0,0 +1,130 @@
+package up
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RenameTag renames a tag by adding the new tag to, and removing the old tag
+// from, every transaction tagged with the old one, since Up has no endpoint
+// for renaming tags. It behaves like MergeTags with a single source tag.
+func (c *Client) RenameTag(
+	ctx context.Context,
+	from string,
+	to string,
+	options ...BulkTagOption,
+) (*BulkTagReport, error) {
+
+	newCtx, span := c.startOperation(ctx, "RenameTag")
+	defer span.End()
+
+	report, err := c.mergeTags(newCtx, []string{from}, to, options)
+	if err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to rename tag %s to %s: %v", from, to, err))
+		span.RecordError(err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// MergeTags merges the source tags into the target tag, by adding the target
+// tag to, and removing the source tags from, every transaction tagged with any
+// of the sources.
+//
+// The target tag is added before the sources are removed wherever the
+// transaction has room for it, so a failure part way through leaves the
+// transaction findable by its source tag and simply running MergeTags again
+// picks up where it left off. For transactions already at MaxTagsPerTransaction
+// tags the sources must be removed first; if adding the target then fails, the
+// transaction is reported with FailureReasonPartial and an ErrBulkTagDropped
+// naming the removed sources. Pass the returned report to BulkTagOptionResume
+// on the next run so any of those that failed are retried.
+// Use BulkTagOptionDryRun to see what would change first.
+func (c *Client) MergeTags(
+	ctx context.Context,
+	sources []string,
+	target string,
+	options ...BulkTagOption,
+) (*BulkTagReport, error) {
+
+	newCtx, span := c.startOperation(ctx, "MergeTags")
+	defer span.End()
+
+	report, err := c.mergeTags(newCtx, sources, target, options)
+	if err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to merge tags into %s: %v", target, err))
+		span.RecordError(err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// mergeTags is the shared implementation of RenameTag and MergeTags.
+func (c *Client) mergeTags(
+	ctx context.Context,
+	sources []string,
+	target string,
+	options []BulkTagOption,
+) (*BulkTagReport, error) {
+
+	// check args.
+	if target == "" || len(sources) == 0 || slices.Contains(sources, "") {
+		return nil, ErrMergeTagsEmptyTag{}
+	}
+	if slices.Contains(sources, target) {
+		return nil, ErrBulkTagConflictingTag{target}
+	}
+	cfg := newBulkTagConfig(options)
+	limiter := &rateLimiter{interval: cfg.interval, metrics: c.metrics}
+
+	// find every transaction tagged with a source tag.
+	fetched := &GetTransactionsResult{
+		Transactions: make(map[string]TransactionDataWrapper),
+	}
+	for _, source := range sources {
+		txns, err := c.ListTransactions(ctx, ListTransactionsOptionTag(source))
+		if err != nil {
+			return nil, fmt.Errorf("listing transactions tagged %s: %w", source, err)
+		}
+		for _, t := range txns {
+			if _, ok := fetched.Transactions[t.ID]; ok {
+				continue
+			}
+			fetched.IDs = append(fetched.IDs, t.ID)
+			fetched.Transactions[t.ID] = t
+		}
+	}
+
+	// fetch any transactions being resumed that weren't found above.
+	var resume []string
+	for _, id := range cfg.resume {
+		if _, ok := fetched.Transactions[id]; !ok {
+			resume = append(resume, id)
+		}
+	}
+	var fetchErrs ErrGetTransactions
+	if len(resume) > 0 {
+		resumed, err := c.GetTransactions(ctx, resume,
+			GetTransactionsOptionWorkers(cfg.workers),
+			getTransactionsOptionLimiter(limiter),
+		)
+		errors.As(err, &fetchErrs)
+		for _, id := range resumed.IDs {
+			fetched.IDs = append(fetched.IDs, id)
+			if t, ok := resumed.Transactions[id]; ok {
+				fetched.Transactions[id] = t
+			}
+		}
+	}
+
+	req := BulkTagRequest{
+		Add:            []string{target},
+		Remove:         sources,
+		TransactionIDs: fetched.IDs,
+	}
+	return c.bulkTag(ctx, cfg, limiter, req, fetched, fetchErrs.Failures), nil
+}
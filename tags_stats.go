@@ -0,0 +1,101 @@
+package up
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// TagStats holds usage statistics for a single tag.
+type TagStats struct {
+	Tag       string           // The tag.
+	Count     int              // The number of transactions tagged.
+	Spend     map[string]Money // The total of outgoing amounts (negative), by currency code.
+	Income    map[string]Money // The total of incoming amounts (positive), by currency code.
+	FirstUsed time.Time        // When the earliest tagged transaction was created; zero if unused.
+	LastUsed  time.Time        // When the latest tagged transaction was created; zero if unused.
+}
+
+// TagStats returns usage statistics for every tag, from a single scan of the
+// transactions matching the given options - use ListTransactionsOptionSince
+// and ListTransactionsOptionUntil to set the time window. Tags are returned in
+// the order given by ListTags, including those with no transactions in the
+// window, followed by any tags that were only found on transactions. An
+// ErrMoneyOverflow is returned if a tag's total doesn't fit in a Money.
+func (c *Client) TagStats(
+	ctx context.Context,
+	options ...ListTransactionsOption,
+) ([]TagStats, error) {
+
+	newCtx, span := c.startOperation(ctx, "TagStats")
+	defer span.End()
+
+	tags, err := c.ListTags(newCtx)
+	if err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to list tags: %v", err))
+		span.RecordError(err)
+		return nil, err
+	}
+	txns, err := c.ListTransactions(newCtx, options...)
+	if err != nil {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to list transactions: %v", err))
+		span.RecordError(err)
+		return nil, err
+	}
+
+	// setup stats for every known tag.
+	var stats []*TagStats
+	byTag := make(map[string]*TagStats, len(tags))
+	newStats := func(tag string) *TagStats {
+		s := &TagStats{
+			Tag:    tag,
+			Spend:  make(map[string]Money),
+			Income: make(map[string]Money),
+		}
+		byTag[tag] = s
+		stats = append(stats, s)
+		return s
+	}
+	for _, t := range tags {
+		newStats(t.ID)
+	}
+
+	// aggregate the transactions into the stats for each of their tags.
+	for _, t := range txns {
+		a := t.Attributes
+		for _, tag := range t.Relationships.Tags.Data {
+			s, ok := byTag[tag.ID]
+			if !ok {
+				s = newStats(tag.ID)
+			}
+			s.Count++
+			totals := s.Income
+			if a.Amount.ValueInBaseUnits < 0 {
+				totals = s.Spend
+			}
+			// totals are keyed by currency, so these can't mismatch; but they
+			// can overflow.
+			total, err := totals[a.Amount.CurrencyCode].Add(a.Amount)
+			if err != nil {
+				span.SetStatus(codes.Error, fmt.Sprintf("failed to total tag %s: %v", tag.ID, err))
+				span.RecordError(err)
+				return nil, err
+			}
+			totals[a.Amount.CurrencyCode] = total
+			if s.FirstUsed.IsZero() || a.CreatedAt.Before(s.FirstUsed) {
+				s.FirstUsed = a.CreatedAt
+			}
+			if a.CreatedAt.After(s.LastUsed) {
+				s.LastUsed = a.CreatedAt
+			}
+		}
+	}
+
+	result := make([]TagStats, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, *s)
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_TagStats(t *testing.T) {
+	tests := map[string]struct {
+		mock *mockRoundTripper
+		want []TagStats
+		err  string
+	}{
+		"tag stats": {
+			mock: &mockRoundTripper{
+				MockFunc: func(req *http.Request) *http.Response {
+					td := tagsTestdata
+					if strings.HasPrefix(req.URL.Path, "/api/v1/transactions") {
+						td = transactionsTestdata
+					}
+					b := td[0].content
+					for i := 0; i < len(td); i++ {
+						if strings.Contains(req.URL.String(), fmt.Sprintf("---%v", i+1)) {
+							b = td[i].content
+							break
+						}
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBuffer(b)),
+						Header:     make(http.Header),
+					}
+				},
+			},
+			want: []TagStats{
+				{Tag: "Holiday"},
+				{
+					Tag:       "Pizza Night",
+					Count:     1,
+					Spend:     map[string]Money{"AUD": {"AUD", "-59.98", -5998}},
+					FirstUsed: time.Date(2024, 11, 5, 7, 25, 12, 0, location),
+					LastUsed:  time.Date(2024, 11, 5, 7, 25, 12, 0, location),
+				},
+				{Tag: "Dining Out"},
+				{Tag: "Shopping"},
+				{Tag: "Fitness"},
+				{
+					Tag:       "Travel",
+					Count:     1,
+					Spend:     map[string]Money{"AUD": {"AUD", "-20.50", -2050}},
+					FirstUsed: time.Date(2024, 11, 6, 8, 45, 0, 0, location),
+					LastUsed:  time.Date(2024, 11, 6, 8, 45, 0, 0, location),
+				},
+				{
+					Tag:       "Business",
+					Count:     1,
+					Spend:     map[string]Money{"AUD": {"AUD", "-45.30", -4530}},
+					FirstUsed: time.Date(2024, 11, 7, 14, 15, 0, 0, location),
+					LastUsed:  time.Date(2024, 11, 7, 14, 15, 0, 0, location),
+				},
+			},
+		},
+		"overflowing totals": {
+			mock: &mockRoundTripper{
+				MockFunc: func(req *http.Request) *http.Response {
+					b := `{"data":[],"links":{}}`
+					if strings.HasPrefix(req.URL.Path, "/api/v1/transactions") {
+						txn := `{"type":"transactions","id":"%v","attributes":{"status":"SETTLED","amount":{"currencyCode":"AUD","value":"-92233720368547758.07","valueInBaseUnits":-9223372036854775807},"createdAt":"2024-11-05T07:25:12+11:00"},"relationships":{"tags":{"data":[{"type":"tags","id":"Big"}]}}}`
+						b = `{"data":[` + fmt.Sprintf(txn, 1) + "," + fmt.Sprintf(txn, 2) + `],"links":{}}`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(b)),
+						Header:     make(http.Header),
+					}
+				},
+			},
+			err: ErrMoneyOverflow{}.Error(),
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		c := newTestClient(t, tt.mock)
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			got, err := c.TagStats(ctx)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("TagStats() returned an unexpected error;\nwant=%v\ngot=%v\n", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("TagStats() returned an error;\nerror=%v\n", err)
+				return
+			}
+
+			// do the lengths match?
+			if len(got) != len(tt.want) {
+				t.Errorf(
+					"TagStats() returned unexpected number of results;\nwant=%d\ngot=%d\n",
+					len(tt.want),
+					len(got),
+				)
+				return
+			}
+
+			// is there a mismatch from what we're expecting vs what we've got?
+			for i := 0; i < len(got); i++ {
+				g, w := got[i], tt.want[i]
+				if g.Tag != w.Tag ||
+					g.Count != w.Count ||
+					len(g.Spend) != len(w.Spend) ||
+					len(g.Income) != len(w.Income) ||
+					!g.FirstUsed.Equal(w.FirstUsed) ||
+					!g.LastUsed.Equal(w.LastUsed) {
+					t.Errorf("mismatch at index %d;\nwant=%+v\ngot=%+v\n", i, w, g)
+					continue
+				}
+				for currency, m := range w.Spend {
+					if g.Spend[currency] != m {
+						t.Errorf("mismatch in spend at index %d;\nwant=%+v\ngot=%+v\n", i, m, g.Spend[currency])
+					}
+				}
+			}
+		})
+	}
+}
@@ -3,12 +3,18 @@ package up
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"path"
 	"slices"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 var tagsTestdata []*testdata
@@ -21,6 +27,21 @@ func init() {
 	}
 }
 
+// newTestTagResource returns the TagResource for the given tag, as returned
+// from the API.
+func newTestTagResource(tag string) TagResource {
+	return TagResource{
+		Object: Object{Type: "tags", ID: tag},
+		Relationships: TagRelationships{
+			Transactions: WrapperOmittable{
+				Links: Links{
+					Related: "https://api.up.com.au/api/v1/transactions?filter%5Btag%5D=" + url.QueryEscape(tag),
+				},
+			},
+		},
+	}
+}
+
 func Test_ListTags(t *testing.T) {
 	tests := map[string]struct {
 		mock *mockRoundTripper
@@ -46,11 +67,11 @@ func Test_ListTags(t *testing.T) {
 				},
 			},
 			want: []TagResource{
-				{Object: Object{Type: "tags", ID: "Holiday"}},
-				{Object: Object{Type: "tags", ID: "Pizza Night"}},
-				{Object: Object{Type: "tags", ID: "Dining Out"}},
-				{Object: Object{Type: "tags", ID: "Shopping"}},
-				{Object: Object{Type: "tags", ID: "Fitness"}},
+				newTestTagResource("Holiday"),
+				newTestTagResource("Pizza Night"),
+				newTestTagResource("Dining Out"),
+				newTestTagResource("Shopping"),
+				newTestTagResource("Fitness"),
 			},
 		},
 	}
@@ -204,3 +225,423 @@ func Test_RemoveTagsFromTransaction(t *testing.T) {
 		})
 	}
 }
+
+func Test_BulkTag(t *testing.T) {
+
+	// the tags currently on each transaction served by the mock.
+	current := map[string][]string{
+		"untagged": {},
+		"tagged":   {"food"},
+		"full":     {"a", "b", "c", "d", "e", "f"},
+		"holiday":  {"holiday"},
+	}
+	mock := &mockRoundTripper{
+		MockFunc: func(req *http.Request) *http.Response {
+			id := strings.Split(strings.TrimPrefix(req.URL.Path, "/api/v1/transactions/"), "/")[0]
+			tags, ok := current[id]
+			code, b := http.StatusOK, []byte(nil)
+			switch {
+			case !ok:
+				code, b = http.StatusNotFound, []byte(`{"errors":[{"status":"404","title":"Not Found"}]}`)
+			case req.Method == http.MethodGet:
+				var resp Wrapper[TransactionDataWrapper]
+				resp.Data.ID = id
+				for _, tag := range tags {
+					resp.Data.Relationships.Tags.Data = append(
+						resp.Data.Relationships.Tags.Data,
+						Object{Type: "tags", ID: tag},
+					)
+				}
+				b, _ = json.Marshal(resp)
+			default:
+				code = http.StatusNoContent
+			}
+			return &http.Response{
+				StatusCode: code,
+				Body:       io.NopCloser(bytes.NewBuffer(b)),
+				Header:     make(http.Header),
+			}
+		},
+	}
+
+	tests := map[string]struct {
+		req  BulkTagRequest
+		want BulkTagReport
+		err  string
+	}{
+		"add tags": {
+			req: BulkTagRequest{
+				Add:            []string{"food"},
+				TransactionIDs: []string{"untagged", "tagged", "full", "missing"},
+			},
+			want: BulkTagReport{
+				Succeeded: []string{"untagged"},
+				Skipped:   []string{"tagged"},
+				Failed: []BulkTagFailure{
+					{ID: "full", Reason: FailureReasonPermanent},
+					{ID: "missing", Reason: FailureReasonNotFound},
+				},
+			},
+		},
+		"replace tags": {
+			req: BulkTagRequest{
+				Add:            []string{"travel"},
+				Remove:         []string{"holiday"},
+				TransactionIDs: []string{"holiday", "untagged"},
+			},
+			want: BulkTagReport{
+				Succeeded: []string{"holiday", "untagged"},
+			},
+		},
+		"conflicting tags": {
+			req: BulkTagRequest{
+				Add:    []string{"food"},
+				Remove: []string{"food"},
+			},
+			err: ErrBulkTagConflictingTag{"food"}.Error(),
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		c := newTestClient(t, mock)
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			got, err := c.BulkTag(ctx, tt.req, BulkTagOptionRateLimit(0))
+
+			// any errors?
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf(
+						"BulkTag() returned an unexpected error;\nwant=%v\ngot=%v\n",
+						tt.err,
+						err,
+					)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("BulkTag() returned an error;\nerror=%v\n", err)
+				return
+			}
+
+			// is there a mismatch from what we're expecting vs what we've got?
+			var failed []string
+			for i, f := range got.Failed {
+				failed = append(failed, f.ID)
+				if i < len(tt.want.Failed) && f.Reason != tt.want.Failed[i].Reason {
+					t.Errorf("BulkTag() returned unexpected failure reason for %s;\nwant=%v\ngot=%v\n",
+						f.ID, tt.want.Failed[i].Reason, f.Reason)
+				}
+			}
+			var wantFailed []string
+			for _, f := range tt.want.Failed {
+				wantFailed = append(wantFailed, f.ID)
+			}
+			if !slices.Equal(got.Succeeded, tt.want.Succeeded) ||
+				!slices.Equal(got.Skipped, tt.want.Skipped) ||
+				!slices.Equal(failed, wantFailed) {
+				t.Errorf(
+					"BulkTag() returned unexpected report;\nwant=%+v\ngot=%+v\n",
+					tt.want,
+					got,
+				)
+			}
+		})
+	}
+}
+
+func Test_BulkTag_rateLimit(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		times []time.Time
+	)
+	mock := &mockRoundTripper{
+		MockFunc: func(req *http.Request) *http.Response {
+			mu.Lock()
+			times = append(times, time.Now())
+			mu.Unlock()
+			var resp Wrapper[TransactionDataWrapper]
+			resp.Data.ID = path.Base(req.URL.Path)
+			resp.Data.Relationships.Tags.Data = []Object{{Type: "tags", ID: "food"}}
+			b, _ := json.Marshal(resp)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBuffer(b)),
+				Header:     make(http.Header),
+			}
+		},
+	}
+	c := newTestClient(t, mock)
+
+	// every transaction is already tagged, so only fetches are sent; they're
+	// spaced out by the rate limit too.
+	interval := 20 * time.Millisecond
+	got, err := c.BulkTag(context.Background(), BulkTagRequest{
+		Add:            []string{"food"},
+		TransactionIDs: []string{"a", "b", "c", "d"},
+	}, BulkTagOptionRateLimit(interval))
+	if err != nil {
+		t.Fatalf("BulkTag() returned an error;\nerror=%v\n", err)
+	}
+	if len(got.Skipped) != 4 || len(times) != 4 {
+		t.Fatalf("BulkTag() sent unexpected requests;\nreport=%+v\nrequests=%v\n", got, len(times))
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	if spread := times[3].Sub(times[0]); spread < 3*interval-5*time.Millisecond {
+		t.Errorf("BulkTag() didn't rate limit fetches; spread=%v, want at least %v", spread, 3*interval)
+	}
+}
+
+// tagStateMock is a mock that keeps the tags for each transaction in memory,
+// serving transaction listing (filtered by tag), fetching, and tagging.
+type tagStateMock struct {
+	mu       sync.Mutex
+	tags     map[string][]string
+	failAdds map[string]bool // Transactions whose next add request fails.
+}
+
+func (m *tagStateMock) transaction(id string) TransactionDataWrapper {
+	var t TransactionDataWrapper
+	t.ID = id
+	for _, tag := range m.tags[id] {
+		t.Relationships.Tags.Data = append(t.Relationships.Tags.Data, Object{Type: "tags", ID: tag})
+	}
+	return t
+}
+
+func (m *tagStateMock) roundTripper() *mockRoundTripper {
+	return &mockRoundTripper{
+		MockFunc: func(req *http.Request) *http.Response {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			code, b := http.StatusOK, []byte(nil)
+			id := strings.Split(strings.TrimPrefix(req.URL.Path, "/api/v1/transactions/"), "/")[0]
+			switch {
+			case req.URL.Path == "/api/v1/transactions":
+				var resp TransactionsPaginationWrapper
+				for id, tags := range m.tags {
+					if slices.Contains(tags, req.URL.Query().Get("filter[tag]")) {
+						resp.Data = append(resp.Data, m.transaction(id))
+					}
+				}
+				b, _ = json.Marshal(resp)
+			case req.Method == http.MethodGet:
+				b, _ = json.Marshal(Wrapper[TransactionDataWrapper]{Data: m.transaction(id)})
+			case req.Method == http.MethodPost && m.failAdds[id]:
+				delete(m.failAdds, id)
+				code, b = http.StatusInternalServerError, []byte(`{"errors":[{"status":"500","title":"Server Error"}]}`)
+			default:
+				var body TagsPaginationWrapper
+				json.NewDecoder(req.Body).Decode(&body)
+				for _, tag := range body.Data {
+					if req.Method == http.MethodPost {
+						m.tags[id] = append(m.tags[id], tag.ID)
+					} else {
+						m.tags[id] = slices.DeleteFunc(m.tags[id], func(t string) bool { return t == tag.ID })
+					}
+				}
+				code = http.StatusNoContent
+			}
+			return &http.Response{
+				StatusCode: code,
+				Body:       io.NopCloser(bytes.NewBuffer(b)),
+				Header:     make(http.Header),
+			}
+		},
+	}
+}
+
+func Test_RenameTag(t *testing.T) {
+	tests := map[string]struct {
+		tags     map[string][]string
+		failAdds map[string]bool
+		options  []BulkTagOption
+		want     map[string][]string
+		failed   []string
+	}{
+		"rename tag": {
+			tags: map[string][]string{
+				"a": {"holiday"},
+				"b": {"food", "holiday"},
+				"c": {"food"},
+				"d": {"holiday", "travel"},
+			},
+			want: map[string][]string{
+				"a": {"travel"},
+				"b": {"food", "travel"},
+				"c": {"food"},
+				"d": {"travel"},
+			},
+		},
+		"rename tag on full transaction": {
+			tags: map[string][]string{
+				"a": {"1", "2", "3", "4", "5", "holiday"},
+			},
+			want: map[string][]string{
+				"a": {"1", "2", "3", "4", "5", "travel"},
+			},
+		},
+		"dry run": {
+			tags: map[string][]string{
+				"a": {"holiday"},
+			},
+			options: []BulkTagOption{BulkTagOptionDryRun()},
+			want: map[string][]string{
+				"a": {"holiday"},
+			},
+		},
+		"partial failure": {
+			tags: map[string][]string{
+				"a": {"holiday"},
+				"b": {"1", "2", "3", "4", "5", "holiday"},
+			},
+			failAdds: map[string]bool{"b": true},
+			want: map[string][]string{
+				"a": {"travel"},
+				"b": {"1", "2", "3", "4", "5"},
+			},
+			failed: []string{"b"},
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		m := &tagStateMock{tags: tt.tags, failAdds: tt.failAdds}
+		c := newTestClient(t, m.roundTripper())
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			options := append([]BulkTagOption{BulkTagOptionRateLimit(0)}, tt.options...)
+			got, err := c.RenameTag(ctx, "holiday", "travel", options...)
+			if err != nil {
+				t.Errorf("RenameTag() returned an error;\nerror=%v\n", err)
+				return
+			}
+
+			// were the expected transactions failed?
+			var failed []string
+			for _, f := range got.Failed {
+				failed = append(failed, f.ID)
+			}
+			if !slices.Equal(failed, tt.failed) {
+				t.Errorf("RenameTag() returned unexpected failures;\nwant=%v\ngot=%v\n", tt.failed, failed)
+			}
+
+			// resuming should finish the job.
+			if len(got.Failed) > 0 {
+				if _, err := c.RenameTag(ctx, "holiday", "travel", append(options, BulkTagOptionResume(got))...); err != nil {
+					t.Errorf("RenameTag() returned an error on resume;\nerror=%v\n", err)
+					return
+				}
+				for _, id := range tt.failed {
+					tt.want[id] = append(tt.want[id], "travel")
+				}
+			}
+
+			// is there a mismatch from what we're expecting vs what we've got?
+			for id, want := range tt.want {
+				if !slices.Equal(m.tags[id], want) {
+					t.Errorf("RenameTag() left unexpected tags on %s;\nwant=%v\ngot=%v\n", id, want, m.tags[id])
+				}
+			}
+		})
+	}
+}
+
+func Test_MergeTags(t *testing.T) {
+	tests := map[string]struct {
+		tags     map[string][]string
+		failAdds map[string]bool
+		want     map[string][]string
+		failed   []string
+		dropped  map[string][]string // The tags reported dropped, by transaction.
+	}{
+		"merge tags": {
+			tags: map[string][]string{
+				"a": {"holiday"},
+				"b": {"holiday", "trip"},
+				"c": {"food", "trip"},
+			},
+			want: map[string][]string{
+				"a": {"travel"},
+				"b": {"travel"},
+				"c": {"food", "travel"},
+			},
+		},
+		"merge tags on full transaction": {
+			tags: map[string][]string{
+				"a": {"1", "2", "3", "4", "holiday", "trip"},
+			},
+			want: map[string][]string{
+				"a": {"1", "2", "3", "4", "travel"},
+			},
+		},
+		"failed add on full transaction": {
+			tags: map[string][]string{
+				"a": {"1", "2", "3", "4", "holiday", "trip"},
+				"b": {"holiday", "trip"},
+			},
+			failAdds: map[string]bool{"a": true, "b": true},
+			want: map[string][]string{
+				"a": {"1", "2", "3", "4"},
+				"b": {"holiday", "trip"},
+			},
+			failed: []string{"a", "b"},
+			dropped: map[string][]string{
+				"a": {"holiday", "trip"},
+			},
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		m := &tagStateMock{tags: tt.tags, failAdds: tt.failAdds}
+		c := newTestClient(t, m.roundTripper())
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			got, err := c.MergeTags(ctx, []string{"holiday", "trip"}, "travel", BulkTagOptionRateLimit(0))
+			if err != nil {
+				t.Errorf("MergeTags() returned an error;\nerror=%v\n", err)
+				return
+			}
+
+			// were transactions left without their tags reported as such?
+			var failed []string
+			for _, f := range got.Failed {
+				failed = append(failed, f.ID)
+				var dropped ErrBulkTagDropped
+				isDropped := errors.As(f.Err, &dropped)
+				want, wantDropped := tt.dropped[f.ID]
+				switch {
+				case isDropped != wantDropped,
+					wantDropped && (f.Reason != FailureReasonPartial || !slices.Equal(dropped.Tags, want)),
+					!wantDropped && f.Reason == FailureReasonPartial:
+					t.Errorf("MergeTags() returned an unexpected failure for %s;\nwant dropped=%v\ngot=%+v\n", f.ID, want, f)
+				}
+			}
+			slices.Sort(failed)
+			if !slices.Equal(failed, tt.failed) {
+				t.Errorf("MergeTags() returned unexpected failures;\nwant=%v\ngot=%v\n", tt.failed, failed)
+			}
+
+			// is there a mismatch from what we're expecting vs what we've got?
+			for id, want := range tt.want {
+				if !slices.Equal(m.tags[id], want) {
+					t.Errorf("MergeTags() left unexpected tags on %s;\nwant=%v\ngot=%v\n", id, want, m.tags[id])
+				}
+			}
+		})
+	}
+}
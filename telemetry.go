@@ -0,0 +1,203 @@
+package up
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// operationKey is the context key for the name of the operation (eg.
+// ListTags) a request is being sent for.
+type operationKey struct{}
+
+// correlationIDKey is the context key for the ID shared by every log line
+// from a single call to the client.
+type correlationIDKey struct{}
+
+// newCorrelationID returns a random ID for correlating the log lines from a
+// single call to the client.
+func newCorrelationID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// tracer returns the tracer the client's spans are started with.
+func (c *Client) tracer() trace.Tracer {
+	if c.tracerProvider != nil {
+		return c.tracerProvider.Tracer(c.tracerName)
+	}
+	return otel.Tracer(c.tracerName)
+}
+
+// startOperation starts a span for the named operation, with the given
+// attributes, and records the name in the returned context so the requests
+// sent for it can be attributed to it. Operations started within another
+// (eg. ListTransactions by Hydrate) share its correlation ID.
+func (c *Client) startOperation(
+	ctx context.Context,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	ctx = context.WithValue(ctx, operationKey{}, name)
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	if !ok {
+		id = newCorrelationID()
+		ctx = context.WithValue(ctx, correlationIDKey{}, id)
+	}
+	attrs = append(attrs, attribute.String("up.correlation_id", id))
+	return c.tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// resourceIDAttribute returns the span attribute for the ID of the resource
+// (eg. transaction, account, or category) an operation is for.
+func resourceIDAttribute(id string) attribute.KeyValue {
+	return attribute.String("up.resource.id", id)
+}
+
+// queryAttributes returns the span attributes for the filters and other
+// query parameters sent with a request (eg. up.query.filter[since]).
+func queryAttributes(queries url.Values) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(queries))
+	for k, v := range queries {
+		attrs = append(attrs, attribute.StringSlice("up.query."+k, v))
+	}
+	return attrs
+}
+
+// recordList adds the number of pages and items fetched by a list call to
+// the span in the given context, and records the pages in the metrics.
+func (c *Client) recordList(ctx context.Context, pages, items int) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("up.page.count", pages),
+		attribute.Int("up.item.count", items),
+	)
+	c.metrics.recordPages(ctx, pages)
+}
+
+// operationFrom returns the name of the operation recorded in the given
+// context, or "unknown" if there isn't one.
+func operationFrom(ctx context.Context) string {
+	if name, ok := ctx.Value(operationKey{}).(string); ok {
+		return name
+	}
+	return "unknown"
+}
+
+// retryReason defines why a request was sent to the API again.
+type retryReason string
+
+const (
+	retryReasonTokenRefreshed retryReason = "token_refreshed" // The token was rejected, and has since been refreshed.
+)
+
+// clientMetrics holds the instruments a client records metrics with.
+type clientMetrics struct {
+	requests      metric.Int64Counter     // Requests sent, by operation, method and status code.
+	duration      metric.Float64Histogram // How long each request took.
+	retries       metric.Int64Counter     // Requests sent again, by operation and reason.
+	rateLimitWait metric.Float64Histogram // How long requests waited for a rate limiter, by operation.
+	pages         metric.Int64Histogram   // Pages fetched per list call, by operation.
+	errors        metric.Int64Counter     // Errors returned by the API, by operation and title.
+}
+
+// newClientMetrics creates the instruments for a client from the given meter
+// provider.
+func newClientMetrics(mp metric.MeterProvider, name string) (*clientMetrics, error) {
+	meter := mp.Meter(name)
+	var (
+		m    clientMetrics
+		err  error
+		errs []error
+	)
+	m.requests, err = meter.Int64Counter("up.client.requests",
+		metric.WithDescription("The number of requests sent to the API."),
+		metric.WithUnit("{request}"),
+	)
+	errs = append(errs, err)
+	m.duration, err = meter.Float64Histogram("up.client.request.duration",
+		metric.WithDescription("How long requests to the API took, including reading the response."),
+		metric.WithUnit("s"),
+	)
+	errs = append(errs, err)
+	m.retries, err = meter.Int64Counter("up.client.retries",
+		metric.WithDescription("The number of requests sent to the API again (eg. with a refreshed token, after the token was rejected)."),
+		metric.WithUnit("{request}"),
+	)
+	errs = append(errs, err)
+	m.rateLimitWait, err = meter.Float64Histogram("up.client.rate_limit.wait",
+		metric.WithDescription("How long requests waited before being sent to the API, to stay under a rate limit."),
+		metric.WithUnit("s"),
+	)
+	errs = append(errs, err)
+	m.pages, err = meter.Int64Histogram("up.client.list.pages",
+		metric.WithDescription("The number of pages fetched by a list call."),
+		metric.WithUnit("{page}"),
+	)
+	errs = append(errs, err)
+	m.errors, err = meter.Int64Counter("up.client.errors",
+		metric.WithDescription("The number of errors returned by the API."),
+		metric.WithUnit("{error}"),
+	)
+	errs = append(errs, err)
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// recordRequest records a request sent for the operation in the given
+// context; a zero statusCode means no response was received.
+func (m *clientMetrics) recordRequest(ctx context.Context, method string, statusCode int, took time.Duration) {
+	attrs := []attribute.KeyValue{
+		attribute.String("up.operation", operationFrom(ctx)),
+		attribute.String("http.request.method", method),
+	}
+	if statusCode != 0 {
+		attrs = append(attrs, attribute.Int("http.response.status_code", statusCode))
+	}
+	set := metric.WithAttributes(attrs...)
+	m.requests.Add(ctx, 1, set)
+	m.duration.Record(ctx, took.Seconds(), set)
+}
+
+// recordRetry records a request being sent again, for the given reason.
+func (m *clientMetrics) recordRetry(ctx context.Context, reason retryReason) {
+	m.retries.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("up.operation", operationFrom(ctx)),
+		attribute.String("up.retry.reason", string(reason)),
+	))
+}
+
+// recordRateLimitWait records how long a request waited for a rate limiter.
+func (m *clientMetrics) recordRateLimitWait(ctx context.Context, waited time.Duration) {
+	m.rateLimitWait.Record(ctx, waited.Seconds(), metric.WithAttributes(
+		attribute.String("up.operation", operationFrom(ctx)),
+	))
+}
+
+// recordPages records the number of pages fetched by a list call.
+func (m *clientMetrics) recordPages(ctx context.Context, pages int) {
+	m.pages.Record(ctx, int64(pages), metric.WithAttributes(
+		attribute.String("up.operation", operationFrom(ctx)),
+	))
+}
+
+// recordErrors records the errors in an error response from the API.
+func (m *clientMetrics) recordErrors(ctx context.Context, statusCode int, errs apiErrorResponse) {
+	for _, e := range errs.Errors {
+		m.errors.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("up.operation", operationFrom(ctx)),
+			attribute.Int("http.response.status_code", statusCode),
+			attribute.String("up.error.title", e.Title),
+		))
+	}
+}
@@ -0,0 +1,193 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_metrics(t *testing.T) {
+
+	// setup client.
+	reader := sdkmetric.NewManualReader()
+	c, err := New(context.Background(), "xxxx",
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithSkipAuthCheck(),
+		WithHttpClient(&http.Client{Transport: &mockRoundTripper{
+			MockFunc: func(req *http.Request) *http.Response {
+				if strings.HasPrefix(req.URL.Path, "/api/v1/transactions") {
+					return &http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"404","title":"Not Found"}]}`)),
+						Header:     make(http.Header),
+					}
+				}
+				b := tagsTestdata[0].content
+				for i := 0; i < len(tagsTestdata); i++ {
+					if strings.Contains(req.URL.String(), fmt.Sprintf("---%v", i+1)) {
+						b = tagsTestdata[i].content
+						break
+					}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(b)),
+					Header:     make(http.Header),
+				}
+			},
+		}}),
+	)
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+
+	// run tests.
+	if _, err := c.ListTags(context.Background()); err != nil {
+		t.Fatalf("ListTags() returned an error; error=%v", err)
+	}
+	if _, err := c.GetTransaction(context.Background(), "missing"); err == nil {
+		t.Fatalf("GetTransaction() didn't return an error")
+	}
+	limiter := &rateLimiter{interval: time.Millisecond, metrics: c.metrics}
+	if _, err := c.GetTransactions(context.Background(), []string{"a", "b"}, getTransactionsOptionLimiter(limiter)); err == nil {
+		t.Fatalf("GetTransactions() didn't return an error")
+	}
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() returned an error; error=%v", err)
+	}
+	got := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name+" "+attrsString(dp.Attributes)] += dp.Value
+				}
+			case metricdata.Histogram[int64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name+" "+attrsString(dp.Attributes)] += dp.Sum
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					got[m.Name+" "+attrsString(dp.Attributes)] += int64(dp.Count)
+				}
+			}
+		}
+	}
+	want := map[string]int64{
+		"up.client.requests http.request.method=GET,http.response.status_code=200,up.operation=ListTags":               3,
+		"up.client.request.duration http.request.method=GET,http.response.status_code=200,up.operation=ListTags":       3,
+		"up.client.list.pages up.operation=ListTags":                                                                   3,
+		"up.client.requests http.request.method=GET,http.response.status_code=404,up.operation=GetTransaction":         3,
+		"up.client.request.duration http.request.method=GET,http.response.status_code=404,up.operation=GetTransaction": 3,
+		"up.client.errors http.response.status_code=404,up.error.title=Not Found,up.operation=GetTransaction":          3,
+		"up.client.rate_limit.wait up.operation=GetTransactions":                                                       2,
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("unexpected metric %q; want=%v, got=%v", k, v, got[k])
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("unexpected metrics recorded;\nwant=%v\ngot=%v\n", want, got)
+	}
+}
+
+// attrsString returns the given attributes as a comparable string.
+func attrsString(set attribute.Set) string {
+	var parts []string
+	for _, kv := range set.ToSlice() {
+		parts = append(parts, string(kv.Key)+"="+kv.Value.Emit())
+	}
+	return strings.Join(parts, ",")
+}
+
+func Test_tracing(t *testing.T) {
+
+	// setup client.
+	recorder := tracetest.NewSpanRecorder()
+	var traceparents []string
+	c, err := New(context.Background(), "xxxx",
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		WithSkipAuthCheck(),
+		WithHttpClient(&http.Client{Transport: &mockRoundTripper{
+			MockFunc: func(req *http.Request) *http.Response {
+				traceparents = append(traceparents, req.Header.Get("traceparent"))
+				b := transactionTestdata.content
+				if strings.HasPrefix(req.URL.Path, "/api/v1/tags") {
+					b = tagsTestdata[0].content
+					for i := 0; i < len(tagsTestdata); i++ {
+						if strings.Contains(req.URL.String(), fmt.Sprintf("---%v", i+1)) {
+							b = tagsTestdata[i].content
+							break
+						}
+					}
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBuffer(b)),
+					Header:     make(http.Header),
+				}
+			},
+		}}),
+	)
+	if err != nil {
+		t.Fatalf("New() returned an error; error=%v", err)
+	}
+
+	// run tests.
+	tags, err := c.ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("ListTags() returned an error; error=%v", err)
+	}
+	if _, err := c.GetTransaction(context.Background(), "abc"); err != nil {
+		t.Fatalf("GetTransaction() returned an error; error=%v", err)
+	}
+	var got []string
+	for _, s := range recorder.Ended() {
+		var attrs []string
+		for _, kv := range s.Attributes() {
+			if kv.Key == "url.full" || kv.Key == "server.address" || kv.Key == "up.correlation_id" {
+				continue
+			}
+			attrs = append(attrs, string(kv.Key)+"="+kv.Value.Emit())
+		}
+		got = append(got, s.Name()+" "+strings.Join(attrs, ","))
+	}
+	want := []string{
+		"New ",
+		"sender http.request.method=GET,up.page=1,http.response.status_code=200",
+		"sender http.request.method=GET,up.page=2,http.response.status_code=200",
+		"sender http.request.method=GET,up.page=3,http.response.status_code=200",
+		fmt.Sprintf("ListTags up.query.page[size]=[\"100\"],up.page.count=3,up.item.count=%d", len(tags)),
+		"sender http.request.method=GET,http.response.status_code=200",
+		"GetTransaction up.resource.id=abc",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected spans recorded;\nwant=%v\ngot=%v\n", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	// every request carries the trace of its sender span.
+	for i, s := range recorder.Ended() {
+		if s.Name() != "sender" {
+			continue
+		}
+		want := fmt.Sprintf("00-%s-%s-01", s.SpanContext().TraceID(), s.SpanContext().SpanID())
+		if !slices.Contains(traceparents, want) {
+			t.Errorf("span %d wasn't propagated; want=%v, got=%v", i, want, traceparents)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package up
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// TokenProvider supplies the personal access token used to authenticate with
+// the API. The client asks for the token before every request, so a provider
+// that reloads its token lets a long-running client survive a token rotation.
+type TokenProvider interface {
+
+	// Token returns the current token.
+	Token(ctx context.Context) (string, error)
+
+	// Refresh reloads the token from its source, after the API rejected the
+	// given token, and returns the new token. If the token has already changed
+	// since it was rejected (eg. refreshed by another request), implementations
+	// should return the current token without reloading it again.
+	Refresh(ctx context.Context, rejected string) (string, error)
+}
+
+// staticTokenProvider is a TokenProvider for a token that never changes.
+type staticTokenProvider struct {
+	token string
+}
+
+// NewStaticTokenProvider returns a TokenProvider that always returns the
+// given token. This is what New uses for the token it's given.
+func NewStaticTokenProvider(token string) TokenProvider {
+	return staticTokenProvider{token}
+}
+
+func (p staticTokenProvider) Token(ctx context.Context) (string, error) {
+	if p.token == "" {
+		return "", ErrClientEmptyToken{}
+	}
+	return p.token, nil
+}
+
+func (p staticTokenProvider) Refresh(ctx context.Context, rejected string) (string, error) {
+	return p.Token(ctx)
+}
+
+// reloadingTokenProvider is a TokenProvider that caches a token loaded from
+// somewhere else, and loads it again when refreshed.
+type reloadingTokenProvider struct {
+	source string                                    // Describes where the token is loaded from, for errors.
+	load   func(ctx context.Context) (string, error) // Loads the token from its source.
+
+	mu    sync.Mutex
+	token string
+}
+
+func (p *reloadingTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" {
+		return p.token, nil
+	}
+	return p.reload(ctx)
+}
+
+func (p *reloadingTokenProvider) Refresh(ctx context.Context, rejected string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.token != "" && p.token != rejected {
+		return p.token, nil
+	}
+	return p.reload(ctx)
+}
+
+// reload loads the token from its source; the caller must hold p.mu.
+func (p *reloadingTokenProvider) reload(ctx context.Context) (string, error) {
+	token, err := p.load(ctx)
+	if err != nil {
+		return "", ErrTokenProviderFailedLoad{p.source, err}
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", ErrTokenProviderEmptyToken{p.source}
+	}
+	p.token = token
+	return token, nil
+}
+
+// NewEnvTokenProvider returns a TokenProvider that reads the token from the
+// given environment variable, and reads it again when the token is rejected.
+func NewEnvTokenProvider(name string) TokenProvider {
+	return &reloadingTokenProvider{
+		source: "environment variable " + name,
+		load: func(ctx context.Context) (string, error) {
+			return os.Getenv(name), nil
+		},
+	}
+}
+
+// NewFileTokenProvider returns a TokenProvider that reads the token from the
+// file at the given path (eg. a mounted secret), and reads it again when the
+// token is rejected. Surrounding whitespace is ignored.
+func NewFileTokenProvider(path string) TokenProvider {
+	return &reloadingTokenProvider{
+		source: "file " + path,
+		load: func(ctx context.Context) (string, error) {
+			b, err := os.ReadFile(path)
+			return string(b), err
+		},
+	}
+}
+
+// NewExecTokenProvider returns a TokenProvider that runs the given command
+// (eg. a password manager's CLI) and uses its output as the token, running it
+// again when the token is rejected. Surrounding whitespace is ignored.
+func NewExecTokenProvider(name string, args ...string) TokenProvider {
+	return &reloadingTokenProvider{
+		source: "command " + name,
+		load: func(ctx context.Context) (string, error) {
+			b, err := exec.CommandContext(ctx, name, args...).Output()
+			return string(b), err
+		},
+	}
+}
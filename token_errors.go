@@ -0,0 +1,24 @@
+package up
+
+import "fmt"
+
+// ErrTokenProviderFailedLoad is returned when a TokenProvider fails to load
+// the token from its source.
+type ErrTokenProviderFailedLoad struct {
+	source string
+	err    error
+}
+
+func (e ErrTokenProviderFailedLoad) Error() string {
+	return fmt.Sprintf("failed to load token from %s: %v", e.source, e.err)
+}
+
+// ErrTokenProviderEmptyToken is returned when a TokenProvider loads an empty
+// token from its source.
+type ErrTokenProviderEmptyToken struct {
+	source string
+}
+
+func (e ErrTokenProviderEmptyToken) Error() string {
+	return fmt.Sprintf("the token loaded from %s is empty", e.source)
+}
@@ -0,0 +1,175 @@
+package up
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_TokenProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	tests := map[string]struct {
+		provider TokenProvider
+		rotate   func(t *testing.T)
+		want     string
+		refresh  string
+		err      string
+	}{
+		"static": {
+			provider: NewStaticTokenProvider("xxxx"),
+			want:     "xxxx",
+			refresh:  "xxxx",
+		},
+		"static empty": {
+			provider: NewStaticTokenProvider(""),
+			err:      ErrClientEmptyToken{}.Error(),
+		},
+		"env": {
+			provider: NewEnvTokenProvider("UP_GO_TEST_TOKEN"),
+			rotate:   func(t *testing.T) { t.Setenv("UP_GO_TEST_TOKEN", "yyyy") },
+			want:     "xxxx",
+			refresh:  "yyyy",
+		},
+		"env empty": {
+			provider: NewEnvTokenProvider("UP_GO_TEST_TOKEN_UNSET"),
+			err:      ErrTokenProviderEmptyToken{"environment variable UP_GO_TEST_TOKEN_UNSET"}.Error(),
+		},
+		"file": {
+			provider: NewFileTokenProvider(path),
+			rotate: func(t *testing.T) {
+				if err := os.WriteFile(path, []byte("yyyy\n"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want:    "xxxx",
+			refresh: "yyyy",
+		},
+		"file missing": {
+			provider: NewFileTokenProvider(filepath.Join(t.TempDir(), "missing")),
+			err:      "failed to load token from file",
+		},
+		"exec": {
+			provider: NewExecTokenProvider("echo", "xxxx"),
+			want:     "xxxx",
+			refresh:  "xxxx",
+		},
+		"exec failed": {
+			provider: NewExecTokenProvider("false"),
+			err:      "failed to load token from command false",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			t.Setenv("UP_GO_TEST_TOKEN", "xxxx")
+			if err := os.WriteFile(path, []byte("  xxxx\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// run tests.
+			got, err := tt.provider.Token(ctx)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Token() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Token() returned an error; error=%v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Token() returned unexpected token; want=%v, got=%v", tt.want, got)
+			}
+			if tt.rotate != nil {
+				tt.rotate(t)
+			}
+			if got, _ := tt.provider.Token(ctx); got != tt.want {
+				t.Errorf("Token() didn't cache the token; want=%v, got=%v", tt.want, got)
+			}
+			refreshed, err := tt.provider.Refresh(ctx, got)
+			if err != nil {
+				t.Fatalf("Refresh() returned an error; error=%v", err)
+			}
+			if refreshed != tt.refresh {
+				t.Errorf("Refresh() returned unexpected token; want=%v, got=%v", tt.refresh, refreshed)
+			}
+		})
+	}
+}
+
+func Test_sender_refreshToken(t *testing.T) {
+	tests := map[string]struct {
+		initial  string
+		rotated  string
+		wantSent []string
+		err      string
+	}{
+		"rotated token is retried": {
+			initial:  "xxxx",
+			rotated:  "yyyy",
+			wantSent: []string{"Bearer xxxx", "Bearer yyyy"},
+		},
+		"unchanged token isn't retried": {
+			initial:  "xxxx",
+			rotated:  "xxxx",
+			wantSent: []string{"Bearer xxxx"},
+			err:      "status_code=401",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("UP_GO_TEST_TOKEN", tt.initial)
+
+			// setup client; only the rotated token is accepted.
+			var sent []string
+			mock := &mockRoundTripper{
+				MockFunc: func(req *http.Request) *http.Response {
+					auth := req.Header.Get("Authorization")
+					sent = append(sent, auth)
+					if tt.rotated == tt.initial || auth != "Bearer "+tt.rotated {
+						return &http.Response{
+							StatusCode: http.StatusUnauthorized,
+							Body:       io.NopCloser(strings.NewReader(`{"errors":[{"status":"401","title":"Not Authorized"}]}`)),
+							Header:     make(http.Header),
+						}
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(bytes.NewBuffer(pingTestdata.content)),
+						Header:     make(http.Header),
+					}
+				},
+			}
+			c, err := New(context.Background(), "",
+				WithTokenProvider(NewEnvTokenProvider("UP_GO_TEST_TOKEN")),
+				WithHttpClient(&http.Client{Transport: mock}),
+				WithSkipAuthCheck(),
+			)
+			if err != nil {
+				t.Fatalf("New() returned an error; error=%v", err)
+			}
+			if _, err := c.tokenProvider.Token(context.Background()); err != nil {
+				t.Fatalf("Token() returned an error; error=%v", err)
+			}
+			t.Setenv("UP_GO_TEST_TOKEN", tt.rotated)
+
+			// run tests.
+			_, err = c.Ping(context.Background())
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("Ping() returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+			} else if err != nil {
+				t.Errorf("Ping() returned an error; error=%v", err)
+			}
+			if strings.Join(sent, ",") != strings.Join(tt.wantSent, ",") {
+				t.Errorf("Ping() sent unexpected tokens; want=%v, got=%v", tt.wantSent, sent)
+			}
+		})
+	}
+}
@@ -12,6 +12,15 @@ const (
 	TransactionStatusSettled TransactionStatus = "SETTLED" // Transaction amount has left your account.
 )
 
+// IsKnown reports whether s is one of the statuses documented by Up.
+func (s TransactionStatus) IsKnown() bool {
+	switch s {
+	case TransactionStatusHeld, TransactionStatusSettled:
+		return true
+	}
+	return false
+}
+
 // TransactionCardPurchaseMethod defines the method used to complete a purchase.
 type TransactionCardPurchaseMethod string
 
@@ -26,6 +35,79 @@ const (
 	TransactionCardPurchaseMethodContactless   TransactionCardPurchaseMethod = "CONTACTLESS"    // Purchased via contactless payment.
 )
 
+// IsKnown reports whether m is one of the purchase methods documented by Up.
+func (m TransactionCardPurchaseMethod) IsKnown() bool {
+	switch m {
+	case TransactionCardPurchaseMethodBarCode,
+		TransactionCardPurchaseMethodOCR,
+		TransactionCardPurchaseMethodCardPin,
+		TransactionCardPurchaseMethodCardDetails,
+		TransactionCardPurchaseMethodCardOnFile,
+		TransactionCardPurchaseMethordEcommerce,
+		TransactionCardPurchaseMethodMagneticStrip,
+		TransactionCardPurchaseMethodContactless:
+		return true
+	}
+	return false
+}
+
+// TransactionType describes the method used for a transaction. Up may add
+// new types at any time, so switch statements over it should always handle
+// unknown values - see IsKnown.
+type TransactionType string
+
+const (
+	TransactionTypePurchase              TransactionType = "Purchase"               // A card purchase.
+	TransactionTypeInternationalPurchase TransactionType = "International Purchase" // A card purchase in a foreign currency.
+	TransactionTypeRefund                TransactionType = "Refund"                 // A refund of a purchase.
+	TransactionTypeCashWithdrawal        TransactionType = "Cash Withdrawal"        // An ATM withdrawal.
+	TransactionTypeTransfer              TransactionType = "Transfer"               // A transfer between your accounts.
+	TransactionTypeScheduledTransfer     TransactionType = "Scheduled Transfer"     // A scheduled transfer between your accounts.
+	TransactionTypeQuickSave             TransactionType = "Quick Save"             // A quick save into a saver.
+	TransactionTypeRoundUp               TransactionType = "Round Up"               // A round up into a saver.
+	TransactionTypeBoost                 TransactionType = "Boost"                  // A boost into a saver.
+	TransactionTypeInterest              TransactionType = "Interest"               // Interest paid into a saver.
+	TransactionTypeSalary                TransactionType = "Salary"                 // A salary payment received.
+	TransactionTypeDirectCredit          TransactionType = "Direct Credit"          // A direct credit received.
+	TransactionTypeDirectDebit           TransactionType = "Direct Debit"           // A direct debit paid.
+	TransactionTypePayment               TransactionType = "Payment"                // A payment made to someone else.
+	TransactionTypeBPAYPayment           TransactionType = "BPAY Payment"           // A BPAY bill payment.
+	TransactionTypeOskoPayment           TransactionType = "Osko Payment"           // A payment made via Osko.
+	TransactionTypeOskoPaymentReceived   TransactionType = "Osko Payment Received"  // A payment received via Osko.
+	TransactionTypePayID                 TransactionType = "PayID"                  // A payment made via PayID.
+	TransactionTypeCashback              TransactionType = "Cashback"               // Cashback paid by Up.
+	TransactionTypeFee                   TransactionType = "Fee"                    // A fee charged.
+)
+
+// IsKnown reports whether t is one of the transaction types defined in this
+// package.
+func (t TransactionType) IsKnown() bool {
+	switch t {
+	case TransactionTypePurchase,
+		TransactionTypeInternationalPurchase,
+		TransactionTypeRefund,
+		TransactionTypeCashWithdrawal,
+		TransactionTypeTransfer,
+		TransactionTypeScheduledTransfer,
+		TransactionTypeQuickSave,
+		TransactionTypeRoundUp,
+		TransactionTypeBoost,
+		TransactionTypeInterest,
+		TransactionTypeSalary,
+		TransactionTypeDirectCredit,
+		TransactionTypeDirectDebit,
+		TransactionTypePayment,
+		TransactionTypeBPAYPayment,
+		TransactionTypeOskoPayment,
+		TransactionTypeOskoPaymentReceived,
+		TransactionTypePayID,
+		TransactionTypeCashback,
+		TransactionTypeFee:
+		return true
+	}
+	return false
+}
+
 // TransactionResourceHoldInfo defines details about a held transaction.
 type TransactionResourceHoldInfo struct {
 	Amount        Money `json:"amount"`
@@ -79,7 +161,7 @@ type TransactionResource struct {
 	CardPurchaseMethod TransactionResourceCardPurchaseMethod `json:"cardPurchaseMethod"`
 	CreatedAt          time.Time                             `json:"createdAt"`
 	SettledAt          time.Time                             `json:"settledAt"`
-	TransactionType    string                                `json:"transactionType"`
+	TransactionType    TransactionType                       `json:"transactionType"`
 	Note               TransactionResourceNote               `json:"note"`
 	PerformingCustomer TransactionResourcePerformingCustomer `json:"performingCustomer"`
 	DeepLinkURL        string                                `json:"deepLinkURL"`
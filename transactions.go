@@ -6,10 +6,11 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // TransactionsPaginationWrapper is a pagination wrapper for a slice of
@@ -75,13 +76,16 @@ func (c *Client) listTransactions(
 		queries: setupQueries(options),
 	}
 
-	for {
+	trace.SpanFromContext(ctx).SetAttributes(queryAttributes(sr.queries)...)
+	for page := 1; ; page++ {
+		sr.page = page
 		var resp TransactionsPaginationWrapper
 		if _, err := c.sender(ctx, sr, &resp); err != nil {
 			return nil, fmt.Errorf("fetching transactions from %s: %w", path, err)
 		}
 		transactions = append(transactions, resp.Data...)
 		if resp.Links.Next == "" {
+			c.recordList(ctx, page, len(transactions))
 			break
 		}
 		sr.path = strings.Replace(resp.Links.Next, c.endpoint, "", 1)
@@ -97,7 +101,7 @@ func (c *Client) ListTransactions(ctx context.Context,
 	options ...ListTransactionsOption,
 ) ([]TransactionDataWrapper, error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "ListTransactions")
+	newCtx, span := c.startOperation(ctx, "ListTransactions")
 	defer span.End()
 
 	txns, err := c.listTransactions(newCtx, "/transactions", options)
@@ -117,7 +121,7 @@ func (c *Client) ListTransactionsByAccount(
 	options ...ListTransactionsOption,
 ) ([]TransactionDataWrapper, error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "ListTransactionsByAccount")
+	newCtx, span := c.startOperation(ctx, "ListTransactionsByAccount", resourceIDAttribute(accountID))
 	defer span.End()
 
 	txns, err := c.listTransactions(newCtx,
@@ -140,7 +144,7 @@ func (c *Client) GetTransaction(
 	id string,
 ) (*TransactionDataWrapper, error) {
 
-	newCtx, span := otel.Tracer(c.tracerName).Start(ctx, "GetTransaction")
+	newCtx, span := c.startOperation(ctx, "GetTransaction", resourceIDAttribute(id))
 	defer span.End()
 
 	var resp struct {
@@ -157,3 +161,108 @@ func (c *Client) GetTransaction(
 	return &resp.Data, nil
 }
 
+// GetTransactionsOption configures a GetTransactions call.
+type GetTransactionsOption func(*getTransactionsConfig)
+
+// getTransactionsConfig holds the configuration for a GetTransactions call.
+type getTransactionsConfig struct {
+	workers int          // The maximum number of transactions fetched at once.
+	limiter *rateLimiter // Spaces out the requests; nil for no limit.
+}
+
+// GetTransactionsOptionWorkers sets the maximum number of transactions fetched
+// from the API at once. Defaults to 10.
+func GetTransactionsOptionWorkers(n int) GetTransactionsOption {
+	return func(c *getTransactionsConfig) {
+		c.workers = n
+	}
+}
+
+// getTransactionsOptionLimiter spaces out the requests with the given
+// rateLimiter, shared with other requests being made (eg. by BulkTag).
+func getTransactionsOptionLimiter(limiter *rateLimiter) GetTransactionsOption {
+	return func(c *getTransactionsConfig) {
+		c.limiter = limiter
+	}
+}
+
+// GetTransactionsResult holds the transactions fetched by GetTransactions.
+type GetTransactionsResult struct {
+	IDs          []string                          // The requested IDs, deduplicated, in the order given.
+	Transactions map[string]TransactionDataWrapper // The successfully fetched transactions, by ID.
+}
+
+// Ordered returns the successfully fetched transactions in the order their IDs
+// were requested.
+func (r GetTransactionsResult) Ordered() []TransactionDataWrapper {
+	txns := make([]TransactionDataWrapper, 0, len(r.Transactions))
+	for _, id := range r.IDs {
+		if t, ok := r.Transactions[id]; ok {
+			txns = append(txns, t)
+		}
+	}
+	return txns
+}
+
+// GetTransactions retrieves many transactions by their IDs concurrently,
+// calling GetTransaction for each unique ID. Transactions that were fetched
+// are always returned in the result, even if others failed; when any fail an
+// ErrGetTransactions is also returned, reporting why each one failed.
+func (c *Client) GetTransactions(
+	ctx context.Context,
+	ids []string,
+	options ...GetTransactionsOption,
+) (*GetTransactionsResult, error) {
+
+	newCtx, span := c.startOperation(ctx, "GetTransactions")
+	defer span.End()
+
+	cfg := getTransactionsConfig{workers: 10}
+	for _, o := range options {
+		o(&cfg)
+	}
+
+	// deduplicate, keeping the first occurrence of each ID.
+	result := &GetTransactionsResult{
+		Transactions: make(map[string]TransactionDataWrapper, len(ids)),
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		result.IDs = append(result.IDs, id)
+	}
+
+	var mu sync.Mutex
+	failures := make([]*GetTransactionsFailure, len(result.IDs))
+	forEachConcurrently(len(result.IDs), cfg.workers, func(i int) {
+		id := result.IDs[i]
+		if err := cfg.limiter.wait(newCtx); err != nil {
+			failures[i] = &GetTransactionsFailure{id, FailureReasonTransient, err}
+			return
+		}
+		t, err := c.GetTransaction(newCtx, id)
+		if err != nil {
+			failures[i] = &GetTransactionsFailure{id, failureReasonFor(err), err}
+			return
+		}
+		mu.Lock()
+		result.Transactions[id] = *t
+		mu.Unlock()
+	})
+
+	var errs ErrGetTransactions
+	for _, f := range failures {
+		if f != nil {
+			errs.Failures = append(errs.Failures, *f)
+		}
+	}
+	if len(errs.Failures) > 0 {
+		span.SetStatus(codes.Error, fmt.Sprintf("failed to get transactions: %v", errs))
+		span.RecordError(errs)
+		return result, errs
+	}
+	return result, nil
+}
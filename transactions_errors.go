@@ -0,0 +1,42 @@
+package up
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetTransactionsFailure describes why a single transaction couldn't be
+// fetched by GetTransactions.
+type GetTransactionsFailure struct {
+	ID     string        // The ID of the transaction.
+	Reason FailureReason // Why the transaction couldn't be fetched.
+	Err    error         // The underlying error.
+}
+
+// ErrGetTransactions is returned by GetTransactions when one or more of the
+// requested transactions couldn't be fetched. Failures are in the order the
+// IDs were requested.
+type ErrGetTransactions struct {
+	Failures []GetTransactionsFailure
+}
+
+func (e ErrGetTransactions) Error() string {
+	var failures []string
+	for _, f := range e.Failures {
+		failures = append(failures, fmt.Sprintf("%s (%s): %v", f.ID, f.Reason, f.Err))
+	}
+	return fmt.Sprintf(
+		"failed to get transactions; count=%v, errors=%s",
+		len(failures),
+		strings.Join(failures, ";"),
+	)
+}
+
+// Unwrap returns the underlying error for each failed transaction.
+func (e ErrGetTransactions) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
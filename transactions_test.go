@@ -3,10 +3,13 @@ package up
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -191,3 +194,100 @@ func Test_ListTransactions(t *testing.T) {
 		})
 	}
 }
+
+var transactionTestdata = newTestdata("transaction")
+
+func Test_GetTransactions(t *testing.T) {
+	tests := map[string]struct {
+		ids       []string
+		want      []string
+		failures  map[string]FailureReason
+		wantCalls int32
+	}{
+		"get transactions": {
+			ids:       []string{"a", "b", "c"},
+			want:      []string{"a", "b", "c"},
+			wantCalls: 3,
+		},
+		"deduplicate ids": {
+			ids:       []string{"b", "a", "b", "a"},
+			want:      []string{"b", "a"},
+			wantCalls: 2,
+		},
+		"report failures": {
+			ids:  []string{"missing", "a", "flaky"},
+			want: []string{"missing", "a", "flaky"},
+			failures: map[string]FailureReason{
+				"missing": FailureReasonNotFound,
+				"flaky":   FailureReasonTransient,
+			},
+			wantCalls: 3,
+		},
+	}
+	for name, tt := range tests {
+
+		// tracing context.
+		ctx := context.Background()
+
+		// setup client.
+		var calls atomic.Int32
+		c := newTestClient(t, &mockRoundTripper{
+			MockFunc: func(req *http.Request) *http.Response {
+				calls.Add(1)
+				code, b := http.StatusOK, transactionTestdata.content
+				switch {
+				case strings.HasSuffix(req.URL.Path, "/missing"):
+					code, b = http.StatusNotFound, []byte(`{"errors":[{"status":"404","title":"Not Found"}]}`)
+				case strings.HasSuffix(req.URL.Path, "/flaky"):
+					code, b = http.StatusInternalServerError, []byte(`{"errors":[{"status":"500","title":"Server Error"}]}`)
+				}
+				return &http.Response{
+					StatusCode: code,
+					Body:       io.NopCloser(bytes.NewBuffer(b)),
+					Header:     make(http.Header),
+				}
+			},
+		})
+
+		// run tests.
+		t.Run(name, func(t *testing.T) {
+			got, err := c.GetTransactions(ctx, tt.ids, GetTransactionsOptionWorkers(2))
+
+			// any errors?
+			var errs ErrGetTransactions
+			if len(tt.failures) > 0 {
+				if !errors.As(err, &errs) {
+					t.Errorf("GetTransactions() returned an unexpected error;\nerror=%v\n", err)
+					return
+				}
+			} else if err != nil {
+				t.Errorf("GetTransactions() returned an error;\nerror=%v\n", err)
+				return
+			}
+			if len(errs.Failures) != len(tt.failures) {
+				t.Errorf(
+					"GetTransactions() returned unexpected number of failures;\nwant=%d\ngot=%d\n",
+					len(tt.failures),
+					len(errs.Failures),
+				)
+			}
+			for _, f := range errs.Failures {
+				if tt.failures[f.ID] != f.Reason {
+					t.Errorf("GetTransactions() returned unexpected failure reason for %s;\nwant=%v\ngot=%v\n",
+						f.ID, tt.failures[f.ID], f.Reason)
+				}
+			}
+
+			// is there a mismatch from what we're expecting vs what we've got?
+			if !slices.Equal(got.IDs, tt.want) {
+				t.Errorf("GetTransactions() returned unexpected ids;\nwant=%v\ngot=%v\n", tt.want, got.IDs)
+			}
+			if n := len(got.Transactions); n != len(tt.want)-len(tt.failures) {
+				t.Errorf("GetTransactions() returned unexpected number of transactions; got=%d\n", n)
+			}
+			if n := calls.Load(); n != tt.wantCalls {
+				t.Errorf("GetTransactions() made unexpected number of calls;\nwant=%d\ngot=%d\n", tt.wantCalls, n)
+			}
+		})
+	}
+}
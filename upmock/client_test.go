@@ -0,0 +1,74 @@
+package upmock
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jmpa-io/up-go"
+)
+
+var errTest = errors.New("an error occurred")
+
+func Test_Client(t *testing.T) {
+	tests := map[string]struct {
+		client    *Client
+		run       func(api up.API) (interface{}, error)
+		want      interface{}
+		wantErr   error
+		wantCalls []Call
+	}{
+		"scripted response": {
+			client: &Client{
+				ListAccountsFunc: func(ctx context.Context, opts ...up.ListAccountsOption) ([]up.AccountResource, error) {
+					return []up.AccountResource{{DisplayName: "Spending"}}, nil
+				},
+			},
+			run: func(api up.API) (interface{}, error) {
+				return api.ListAccounts(context.Background())
+			},
+			want:      []up.AccountResource{{DisplayName: "Spending"}},
+			wantCalls: []Call{{Method: "ListAccounts", Args: []interface{}{[]up.ListAccountsOption(nil)}}},
+		},
+		"scripted error": {
+			client: &Client{
+				AddTagsToTransactionFunc: func(ctx context.Context, id string, tags []string) error {
+					return errTest
+				},
+			},
+			run: func(api up.API) (interface{}, error) {
+				return nil, api.AddTagsToTransaction(context.Background(), "txn", []string{"a"})
+			},
+			wantErr:   errTest,
+			wantCalls: []Call{{Method: "AddTagsToTransaction", Args: []interface{}{"txn", []string{"a"}}}},
+		},
+		"not scripted": {
+			client: &Client{},
+			run: func(api up.API) (interface{}, error) {
+				return api.GetTransaction(context.Background(), "txn")
+			},
+			want:      (*up.TransactionDataWrapper)(nil),
+			wantErr:   ErrNotScripted{"GetTransaction"},
+			wantCalls: []Call{{Method: "GetTransaction", Args: []interface{}{"txn"}}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.run(tt.client)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("call returned an unexpected error; want=%v, got=%v", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("call returned unexpected response;\nwant=%+v\ngot=%+v\n", tt.want, got)
+			}
+			if calls := tt.client.Calls(); !reflect.DeepEqual(calls, tt.wantCalls) {
+				t.Errorf("unexpected calls recorded;\nwant=%+v\ngot=%+v\n", tt.wantCalls, calls)
+			}
+			tt.client.Reset()
+			if calls := tt.client.Calls(); len(calls) != 0 {
+				t.Errorf("Reset() didn't forget calls; got=%+v", calls)
+			}
+		})
+	}
+}
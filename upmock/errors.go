@@ -0,0 +1,13 @@
+package upmock
+
+import "fmt"
+
+// ErrNotScripted is returned when a method of a Client is called without a
+// func scripting its response.
+type ErrNotScripted struct {
+	Method string // The method called.
+}
+
+func (e ErrNotScripted) Error() string {
+	return fmt.Sprintf("upmock: no response scripted for %s; set %sFunc", e.Method, e.Method)
+}
@@ -0,0 +1,238 @@
+// Command gen generates the upmock Client from the up.API interface, with a
+// func and a recording method for each of its methods. It's run by go
+// generate in the upmock package.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func main() {
+
+	// parse flags.
+	api := flag.String("api", "../api.go", "the file declaring the up.API interface")
+	out := flag.String("out", "client.go", "the file to write the generated Client to")
+	flag.Parse()
+
+	// generate client.
+	b, err := generate(*api)
+	if err != nil {
+		fmt.Printf("failed to generate client: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, b, 0o644); err != nil {
+		fmt.Printf("failed to write client: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// method is a method of up.API.
+type method struct {
+	name    string
+	group   string        // The comment the method is grouped under in up.API (eg. "accounts."), if any.
+	typ     *ast.FuncType // The method's signature, with types from package up qualified.
+	args    []string      // The names of the params recorded, excluding the context.
+	call    []string      // The args the func is called with.
+	zero    []string      // The values returned when the func isn't set, excluding the error.
+	errored bool          // Whether the method returns an error last.
+}
+
+// generate returns the source of the Client, generated from the API interface
+// in the given file.
+func generate(path string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	var iface *ast.InterfaceType
+	ast.Inspect(f, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == "API" {
+			iface, _ = ts.Type.(*ast.InterfaceType)
+		}
+		return iface == nil
+	})
+	if iface == nil {
+		return nil, fmt.Errorf("no API interface in %s", path)
+	}
+	var methods []method
+	for _, field := range iface.Methods.List {
+		typ, ok := field.Type.(*ast.FuncType)
+		if !ok || len(field.Names) != 1 {
+			return nil, fmt.Errorf("unsupported API member at %s", fset.Position(field.Pos()))
+		}
+		m, err := newMethod(field.Names[0].Name, qualify(typ).(*ast.FuncType))
+		if err != nil {
+			return nil, err
+		}
+		if field.Doc != nil {
+			m.group = strings.TrimSpace(field.Doc.Text())
+		}
+		methods = append(methods, m)
+	}
+
+	// write the client.
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/gen from up.API; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package upmock\n\nimport (\n")
+	for _, imp := range f.Imports {
+		fmt.Fprintf(&buf, "\t%s\n", imp.Path.Value)
+	}
+	fmt.Fprintf(&buf, "\t\"sync\"\n\n\t\"github.com/jmpa-io/up-go\"\n)\n\n")
+	fmt.Fprintf(&buf, "// Client is a fake up.API. Each method calls its matching func (eg.\n")
+	fmt.Fprintf(&buf, "// ListAccounts calls ListAccountsFunc), or returns ErrNotScripted when it's\n")
+	fmt.Fprintf(&buf, "// nil; methods that don't return an error return their zero values instead.\n")
+	fmt.Fprintf(&buf, "// The funcs should be set before the Client is used; it's otherwise safe to\n")
+	fmt.Fprintf(&buf, "// use concurrently.\n")
+	fmt.Fprintf(&buf, "type Client struct {\n")
+	for _, m := range methods {
+		if m.group != "" {
+			fmt.Fprintf(&buf, "\n\t// %s\n", m.group)
+		}
+		fmt.Fprintf(&buf, "\t%sFunc %s\n", m.name, node(m.typ))
+	}
+	fmt.Fprintf(&buf, "\n\t// calls.\n\tmu    sync.Mutex\n\tcalls []Call\n}\n\n")
+	fmt.Fprintf(&buf, "// Client must implement up.API.\nvar _ up.API = (*Client)(nil)\n\n// ---\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\n// %s calls %sFunc.\n", m.name, m.name)
+		fmt.Fprintf(&buf, "func (c *Client) %s%s {\n", m.name, strings.TrimPrefix(node(m.typ), "func"))
+		fmt.Fprintf(&buf, "\tc.record(%s)\n", strings.Join(append([]string{strconv.Quote(m.name)}, m.args...), ", "))
+		fmt.Fprintf(&buf, "\tif c.%sFunc == nil {\n", m.name)
+		zero := m.zero
+		if m.errored {
+			zero = append(zero, fmt.Sprintf("ErrNotScripted{%q}", m.name))
+		}
+		if len(zero) > 0 {
+			fmt.Fprintf(&buf, "\t\treturn %s\n", strings.Join(zero, ", "))
+		} else {
+			fmt.Fprintf(&buf, "\t\treturn\n")
+		}
+		fmt.Fprintf(&buf, "\t}\n")
+		ret := "return "
+		if m.typ.Results == nil {
+			ret = ""
+		}
+		fmt.Fprintf(&buf, "\t%sc.%sFunc(%s)\n}\n", ret, m.name, strings.Join(m.call, ", "))
+	}
+	return format.Source(buf.Bytes())
+}
+
+// newMethod returns the method with the given name and (qualified) signature.
+func newMethod(name string, typ *ast.FuncType) (method, error) {
+	m := method{name: name, typ: typ}
+	for i, p := range typ.Params.List {
+		if len(p.Names) == 0 {
+			return m, fmt.Errorf("%s: param %d is unnamed", name, i)
+		}
+		for _, n := range p.Names {
+			arg := n.Name
+			if _, ok := p.Type.(*ast.Ellipsis); ok {
+				arg += "..."
+			}
+			m.call = append(m.call, arg)
+			if node(p.Type) != "context.Context" {
+				m.args = append(m.args, n.Name)
+			}
+		}
+	}
+	if typ.Results == nil {
+		return m, nil
+	}
+	for i, r := range typ.Results.List {
+		if i == len(typ.Results.List)-1 && node(r.Type) == "error" {
+			m.errored = true
+			break
+		}
+		m.zero = append(m.zero, zeroValue(r.Type))
+	}
+	return m, nil
+}
+
+// zeroValue returns the zero value of the given type, assuming any named type
+// that isn't built in is a struct.
+func zeroValue(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType, *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return "nil"
+	case *ast.Ident:
+		switch {
+		case t.Name == "string":
+			return `""`
+		case t.Name == "bool":
+			return "false"
+		case t.Name == "error" || t.Name == "any":
+			return "nil"
+		case !ast.IsExported(t.Name):
+			return "0"
+		}
+	}
+	return node(t) + "{}"
+}
+
+// qualify returns the given expression with the identifiers of the types
+// declared in package up (those that are exported) qualified with "up.".
+func qualify(e ast.Expr) ast.Expr {
+	return rewriteIdents(e, func(id *ast.Ident) ast.Expr {
+		if unicode.IsUpper(rune(id.Name[0])) {
+			return &ast.SelectorExpr{X: ast.NewIdent("up"), Sel: id}
+		}
+		return id
+	})
+}
+
+// rewriteIdents returns a copy of the given type expression, with every
+// unqualified identifier in it replaced by fn.
+func rewriteIdents(e ast.Expr, fn func(*ast.Ident) ast.Expr) ast.Expr {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return fn(e)
+	case *ast.SelectorExpr:
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: rewriteIdents(e.X, fn)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: rewriteIdents(e.Elt, fn)}
+	case *ast.MapType:
+		return &ast.MapType{Key: rewriteIdents(e.Key, fn), Value: rewriteIdents(e.Value, fn)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: rewriteIdents(e.Value, fn)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: rewriteIdents(e.Elt, fn)}
+	case *ast.FuncType:
+		return &ast.FuncType{
+			Params:  applyFields(e.Params, fn),
+			Results: applyFields(e.Results, fn),
+		}
+	}
+	return e
+}
+
+// applyFields returns a copy of the given fields, with fn applied to their
+// types.
+func applyFields(fl *ast.FieldList, fn func(*ast.Ident) ast.Expr) *ast.FieldList {
+	if fl == nil {
+		return nil
+	}
+	out := &ast.FieldList{}
+	for _, f := range fl.List {
+		out.List = append(out.List, &ast.Field{Names: f.Names, Type: rewriteIdents(f.Type, fn)})
+	}
+	return out
+}
+
+// node returns the source of the given node.
+func node(n ast.Node) string {
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), n)
+	return buf.String()
+}
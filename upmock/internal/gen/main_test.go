@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func Test_generate(t *testing.T) {
+	want, err := os.ReadFile("../../client.go")
+	if err != nil {
+		t.Fatalf("failed to read client; error=%v", err)
+	}
+	got, err := generate("../../../api.go")
+	if err != nil {
+		t.Fatalf("generate() returned an error; error=%v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generate() returned a different client to upmock/client.go; run go generate ./upmock")
+	}
+}
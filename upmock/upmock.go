@@ -0,0 +1,48 @@
+// Package upmock provides a fake implementation of up.API, for testing code
+// that depends on the interface rather than a real *up.Client. Responses and
+// errors are scripted per method by setting its func, and every call is
+// recorded for checking afterwards.
+//
+// The Client is generated from up.API by internal/gen; run go generate after
+// changing up.API, and the var _ up.API assertion stops the package compiling
+// until it's been run.
+package upmock
+
+//go:generate go run ./internal/gen -api ../api.go -out client.go
+
+import "slices"
+
+// Call is a call made to a Client.
+type Call struct {
+	Method string        // The name of the method called (eg. "ListAccounts").
+	Args   []interface{} // The arguments given, excluding the context.
+}
+
+// Calls returns the calls made to the Client, in order. If any methods are
+// given, only calls to those methods are returned.
+func (c *Client) Calls(methods ...string) []Call {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var calls []Call
+	for _, call := range c.calls {
+		if len(methods) > 0 && !slices.Contains(methods, call.Method) {
+			continue
+		}
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// Reset forgets the calls made to the Client; its funcs are left as they are.
+func (c *Client) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = nil
+}
+
+// record records a call to the given method.
+func (c *Client) record(method string, args ...interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, Call{Method: method, Args: args})
+}
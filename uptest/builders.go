@@ -0,0 +1,467 @@
+package uptest
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmpa-io/up-go"
+)
+
+// DefaultTime is the time resources are created at by the builders, unless
+// changed.
+var DefaultTime = time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+// builderIDs is used to give every built resource a unique ID.
+var builderIDs atomic.Int64
+
+// newBuilderID returns a new, unique, ID for a built resource of the given
+// type.
+func newBuilderID(resourceType string) string {
+	return fmt.Sprintf("%s-%d", resourceType, builderIDs.Add(1))
+}
+
+// money returns Money for the given decimal value (eg. -12.50) of the given
+// currency, rounded to the currency's minor units.
+func money(value float64, currencyCode string) up.Money {
+	exponent := 2
+	if c, ok := up.LookupCurrency(currencyCode); ok {
+		exponent = c.Exponent
+	}
+	return up.NewMoney(currencyCode, int64(math.Round(value*math.Pow10(exponent))))
+}
+
+// ---
+
+// TransactionBuilder builds an up.TransactionDataWrapper. By default, the
+// transaction is a held, categorizable, purchase of 0 AUD, created at
+// DefaultTime, with a unique ID.
+type TransactionBuilder struct {
+	t up.TransactionDataWrapper
+}
+
+// NewTransaction returns a new TransactionBuilder.
+func NewTransaction() *TransactionBuilder {
+	b := &TransactionBuilder{}
+	b.t.Object = up.Object{Type: "transactions", ID: newBuilderID("transaction")}
+	b.t.Attributes.Status = up.TransactionStatusHeld
+	b.t.Attributes.IsCategorizable = true
+	b.t.Attributes.Amount = money(0, "AUD")
+	b.t.Attributes.CreatedAt = DefaultTime
+	b.t.Attributes.TransactionType = up.TransactionTypePurchase
+	return b
+}
+
+// ID sets the ID of the transaction.
+func (b *TransactionBuilder) ID(id string) *TransactionBuilder {
+	b.t.ID = id
+	return b
+}
+
+// Description sets the description, and raw text, of the transaction.
+func (b *TransactionBuilder) Description(description string) *TransactionBuilder {
+	b.t.Attributes.Description = description
+	b.t.Attributes.RawText = description
+	return b
+}
+
+// RawText sets the raw text of the transaction, as sent by the merchant.
+func (b *TransactionBuilder) RawText(rawText string) *TransactionBuilder {
+	b.t.Attributes.RawText = rawText
+	return b
+}
+
+// Message sets the message of the transaction.
+func (b *TransactionBuilder) Message(message string) *TransactionBuilder {
+	b.t.Attributes.Message = message
+	return b
+}
+
+// Note sets the note of the transaction.
+func (b *TransactionBuilder) Note(text string) *TransactionBuilder {
+	b.t.Attributes.Note.Text = text
+	return b
+}
+
+// Amount sets the amount of the transaction (eg. -12.50 for a purchase).
+func (b *TransactionBuilder) Amount(value float64, currencyCode string) *TransactionBuilder {
+	b.t.Attributes.Amount = money(value, currencyCode)
+	return b
+}
+
+// AmountInBaseUnits sets the amount of the transaction, in the currency's
+// base units (eg. -1250 for a purchase of -12.50 AUD).
+func (b *TransactionBuilder) AmountInBaseUnits(units int64, currencyCode string) *TransactionBuilder {
+	b.t.Attributes.Amount = up.NewMoney(currencyCode, units)
+	return b
+}
+
+// ForeignAmount sets the amount of the transaction in a foreign currency,
+// making it an international purchase.
+func (b *TransactionBuilder) ForeignAmount(value float64, currencyCode string) *TransactionBuilder {
+	return b.ForeignAmountInBaseUnits(money(value, currencyCode).ValueInBaseUnits, currencyCode)
+}
+
+// ForeignAmountInBaseUnits sets the amount of the transaction in a foreign
+// currency, in that currency's base units, making it an international
+// purchase.
+func (b *TransactionBuilder) ForeignAmountInBaseUnits(units int64, currencyCode string) *TransactionBuilder {
+	b.t.Attributes.ForeignAmount = up.NewMoney(currencyCode, units)
+	b.t.Attributes.TransactionType = up.TransactionTypeInternationalPurchase
+	return b
+}
+
+// RoundUp sets the round-up amount of the transaction, in its currency.
+func (b *TransactionBuilder) RoundUp(value float64) *TransactionBuilder {
+	b.t.Attributes.RoundUp.Amount = money(value, b.t.Attributes.Amount.CurrencyCode)
+	return b
+}
+
+// RoundUpInBaseUnits sets the round-up amount of the transaction, in its
+// currency's base units.
+func (b *TransactionBuilder) RoundUpInBaseUnits(units int64) *TransactionBuilder {
+	b.t.Attributes.RoundUp.Amount = up.NewMoney(b.t.Attributes.Amount.CurrencyCode, units)
+	return b
+}
+
+// Type sets the type of the transaction.
+func (b *TransactionBuilder) Type(t up.TransactionType) *TransactionBuilder {
+	b.t.Attributes.TransactionType = t
+	return b
+}
+
+// CardPurchase sets the card, and method, used for the transaction.
+func (b *TransactionBuilder) CardPurchase(method up.TransactionCardPurchaseMethod, cardNumberSuffix string) *TransactionBuilder {
+	b.t.Attributes.CardPurchaseMethod = up.TransactionResourceCardPurchaseMethod{
+		CardNumberSuffix: cardNumberSuffix,
+		Method:           method,
+	}
+	return b
+}
+
+// PerformingCustomer sets the name of the customer who made the transaction
+// (eg. for joint accounts).
+func (b *TransactionBuilder) PerformingCustomer(displayName string) *TransactionBuilder {
+	b.t.Attributes.PerformingCustomer.DisplayName = displayName
+	return b
+}
+
+// HoldInfo sets the amounts the transaction was held for to its current
+// amounts, as for card purchases.
+func (b *TransactionBuilder) HoldInfo() *TransactionBuilder {
+	b.t.Attributes.HoldInfo = up.TransactionResourceHoldInfo{
+		Amount:        b.t.Attributes.Amount,
+		ForeignAmount: b.t.Attributes.ForeignAmount,
+	}
+	return b
+}
+
+// CreatedAt sets when the transaction was created.
+func (b *TransactionBuilder) CreatedAt(t time.Time) *TransactionBuilder {
+	b.t.Attributes.CreatedAt = t
+	return b
+}
+
+// Held marks the transaction as held.
+func (b *TransactionBuilder) Held() *TransactionBuilder {
+	b.t.Attributes.Status = up.TransactionStatusHeld
+	b.t.Attributes.SettledAt = time.Time{}
+	return b
+}
+
+// Settled marks the transaction as settled at the given time.
+func (b *TransactionBuilder) Settled(at time.Time) *TransactionBuilder {
+	b.t.Attributes.Status = up.TransactionStatusSettled
+	b.t.Attributes.SettledAt = at
+	return b
+}
+
+// Account sets the account the transaction belongs to.
+func (b *TransactionBuilder) Account(id string) *TransactionBuilder {
+	b.t.Relationships.Account.Data = up.Object{Type: "accounts", ID: id}
+	return b
+}
+
+// TransferAccount sets the account the transaction is a transfer to or from,
+// making it a transfer.
+func (b *TransactionBuilder) TransferAccount(id string) *TransactionBuilder {
+	b.t.Relationships.TransferAccount.Data = up.Object{Type: "accounts", ID: id}
+	b.t.Attributes.TransactionType = up.TransactionTypeTransfer
+	return b
+}
+
+// Category sets the category of the transaction.
+func (b *TransactionBuilder) Category(id string) *TransactionBuilder {
+	b.t.Relationships.Category.Data = up.Object{Type: "categories", ID: id}
+	return b
+}
+
+// ParentCategory sets the parent category of the transaction's category.
+func (b *TransactionBuilder) ParentCategory(id string) *TransactionBuilder {
+	b.t.Relationships.ParentCategory.Data = up.Object{Type: "categories", ID: id}
+	return b
+}
+
+// Uncategorizable marks the transaction as not being able to be categorized.
+func (b *TransactionBuilder) Uncategorizable() *TransactionBuilder {
+	b.t.Attributes.IsCategorizable = false
+	return b
+}
+
+// Tags adds the given tags to the transaction.
+func (b *TransactionBuilder) Tags(tags ...string) *TransactionBuilder {
+	for _, tag := range tags {
+		b.t.Relationships.Tags.Data = append(b.t.Relationships.Tags.Data, up.Object{Type: "tags", ID: tag})
+	}
+	return b
+}
+
+// Attachment sets the attachment of the transaction.
+func (b *TransactionBuilder) Attachment(id string) *TransactionBuilder {
+	b.t.Relationships.Attachment.Data = up.Object{Type: "attachments", ID: id}
+	return b
+}
+
+// Build returns the built transaction.
+func (b *TransactionBuilder) Build() up.TransactionDataWrapper {
+	t := b.t
+	t.Relationships.Tags.Data = append([]up.Object(nil), b.t.Relationships.Tags.Data...)
+	return t
+}
+
+// ---
+
+// AccountBuilder builds an up.AccountDataWrapper. By default, the account is
+// an individual transactional account, with a balance of 0 AUD, created at
+// DefaultTime, with a unique ID.
+type AccountBuilder struct {
+	a up.AccountDataWrapper
+}
+
+// NewAccount returns a new AccountBuilder.
+func NewAccount() *AccountBuilder {
+	b := &AccountBuilder{}
+	b.a.Object = up.Object{Type: "accounts", ID: newBuilderID("account")}
+	b.a.Attributes.DisplayName = "Spending"
+	b.a.Attributes.AccountType = up.AccountTypeTransactional
+	b.a.Attributes.OwnershipType = up.AccountOwnershipTypeIndividual
+	b.a.Attributes.Balance = money(0, "AUD")
+	b.a.Attributes.CreatedAt = DefaultTime
+	return b
+}
+
+// ID sets the ID of the account.
+func (b *AccountBuilder) ID(id string) *AccountBuilder {
+	b.a.ID = id
+	return b
+}
+
+// DisplayName sets the display name of the account.
+func (b *AccountBuilder) DisplayName(name string) *AccountBuilder {
+	b.a.Attributes.DisplayName = name
+	return b
+}
+
+// Type sets the type of the account.
+func (b *AccountBuilder) Type(t up.AccountType) *AccountBuilder {
+	b.a.Attributes.AccountType = t
+	return b
+}
+
+// Joint marks the account as owned by multiple people.
+func (b *AccountBuilder) Joint() *AccountBuilder {
+	b.a.Attributes.OwnershipType = up.AccountOwnershipTypeJoint
+	return b
+}
+
+// Balance sets the balance of the account.
+func (b *AccountBuilder) Balance(value float64, currencyCode string) *AccountBuilder {
+	b.a.Attributes.Balance = money(value, currencyCode)
+	return b
+}
+
+// CreatedAt sets when the account was created.
+func (b *AccountBuilder) CreatedAt(t time.Time) *AccountBuilder {
+	b.a.Attributes.CreatedAt = t
+	return b
+}
+
+// Build returns the built account.
+func (b *AccountBuilder) Build() up.AccountDataWrapper {
+	return b.a
+}
+
+// ---
+
+// CategoryBuilder builds an up.CategoryData. By default, the category is a
+// top-level category named after its ID.
+type CategoryBuilder struct {
+	c up.CategoryData
+}
+
+// NewCategory returns a new CategoryBuilder for the category with the given
+// ID (eg. "groceries").
+func NewCategory(id string) *CategoryBuilder {
+	b := &CategoryBuilder{}
+	b.c.Object = up.Object{Type: "categories", ID: id}
+	b.c.Attributes.Name = id
+	return b
+}
+
+// Name sets the name of the category.
+func (b *CategoryBuilder) Name(name string) *CategoryBuilder {
+	b.c.Attributes.Name = name
+	return b
+}
+
+// Parent sets the parent of the category.
+func (b *CategoryBuilder) Parent(id string) *CategoryBuilder {
+	b.c.Relationships.Parent.Data = &up.Object{Type: "categories", ID: id}
+	return b
+}
+
+// Children adds the given children to the category.
+func (b *CategoryBuilder) Children(ids ...string) *CategoryBuilder {
+	for _, id := range ids {
+		b.c.Relationships.Children.Data = append(b.c.Relationships.Children.Data, up.Object{Type: "categories", ID: id})
+	}
+	return b
+}
+
+// Build returns the built category.
+func (b *CategoryBuilder) Build() up.CategoryData {
+	c := b.c
+	if b.c.Relationships.Parent.Data != nil {
+		parent := *b.c.Relationships.Parent.Data
+		c.Relationships.Parent.Data = &parent
+	}
+	c.Relationships.Children.Data = append([]up.Object(nil), b.c.Relationships.Children.Data...)
+	return c
+}
+
+// ---
+
+// TagBuilder builds an up.TagResource.
+type TagBuilder struct {
+	t up.TagResource
+}
+
+// NewTag returns a new TagBuilder for the tag with the given ID (eg. "food").
+func NewTag(id string) *TagBuilder {
+	b := &TagBuilder{}
+	b.t.Object = up.Object{Type: "tags", ID: id}
+	return b
+}
+
+// TransactionsLink sets the link to the tag's transactions.
+func (b *TagBuilder) TransactionsLink(related string) *TagBuilder {
+	b.t.Relationships.Transactions.Links.Related = related
+	return b
+}
+
+// Build returns the built tag.
+func (b *TagBuilder) Build() up.TagResource {
+	return b.t
+}
+
+// ---
+
+// AttachmentBuilder builds an Attachment. By default, the attachment is a
+// JPEG, created at DefaultTime, with a unique ID.
+type AttachmentBuilder struct {
+	a Attachment
+}
+
+// NewAttachment returns a new AttachmentBuilder.
+func NewAttachment() *AttachmentBuilder {
+	b := &AttachmentBuilder{}
+	b.a.ID = newBuilderID("attachment")
+	b.a.CreatedAt = DefaultTime
+	b.a.FileURL = "https://example.com/" + b.a.ID + ".jpg"
+	b.a.FileExtension = "jpg"
+	b.a.FileContentType = "image/jpeg"
+	return b
+}
+
+// ID sets the ID of the attachment.
+func (b *AttachmentBuilder) ID(id string) *AttachmentBuilder {
+	b.a.ID = id
+	return b
+}
+
+// Transaction sets the transaction the attachment belongs to.
+func (b *AttachmentBuilder) Transaction(id string) *AttachmentBuilder {
+	b.a.TransactionID = id
+	return b
+}
+
+// File sets the URL, extension (eg. "pdf"), and content type (eg.
+// "application/pdf") of the attachment's file.
+func (b *AttachmentBuilder) File(url, extension, contentType string) *AttachmentBuilder {
+	b.a.FileURL = url
+	b.a.FileExtension = extension
+	b.a.FileContentType = contentType
+	return b
+}
+
+// CreatedAt sets when the attachment was created.
+func (b *AttachmentBuilder) CreatedAt(t time.Time) *AttachmentBuilder {
+	b.a.CreatedAt = t
+	return b
+}
+
+// Build returns the built attachment.
+func (b *AttachmentBuilder) Build() Attachment {
+	return b.a
+}
+
+// ---
+
+// WebhookEventBuilder builds a WebhookEvent. By default, the event is a ping,
+// created at DefaultTime, with a unique ID.
+type WebhookEventBuilder struct {
+	e WebhookEvent
+}
+
+// NewWebhookEvent returns a new WebhookEventBuilder for an event sent to the
+// webhook with the given ID.
+func NewWebhookEvent(webhookID string) *WebhookEventBuilder {
+	b := &WebhookEventBuilder{}
+	b.e.Object = up.Object{Type: "webhook-events", ID: newBuilderID("webhook-event")}
+	b.e.Attributes.EventType = WebhookEventTypePing
+	b.e.Attributes.CreatedAt = DefaultTime
+	b.e.Relationships.Webhook.Data = up.Object{Type: "webhooks", ID: webhookID}
+	return b
+}
+
+// ID sets the ID of the event.
+func (b *WebhookEventBuilder) ID(id string) *WebhookEventBuilder {
+	b.e.ID = id
+	return b
+}
+
+// Transaction makes the event one of the given type (eg.
+// WebhookEventTypeTransactionCreated), about the transaction with the given
+// ID.
+func (b *WebhookEventBuilder) Transaction(eventType WebhookEventType, id string) *WebhookEventBuilder {
+	b.e.Attributes.EventType = eventType
+	b.e.Relationships.Transaction = &up.Wrapper[up.Object]{Data: up.Object{Type: "transactions", ID: id}}
+	return b
+}
+
+// CreatedAt sets when the event was created.
+func (b *WebhookEventBuilder) CreatedAt(t time.Time) *WebhookEventBuilder {
+	b.e.Attributes.CreatedAt = t
+	return b
+}
+
+// Build returns the built event.
+func (b *WebhookEventBuilder) Build() WebhookEvent {
+	e := b.e
+	if b.e.Relationships.Transaction != nil {
+		transaction := *b.e.Relationships.Transaction
+		e.Relationships.Transaction = &transaction
+	}
+	return e
+}
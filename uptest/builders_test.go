@@ -0,0 +1,101 @@
+package uptest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jmpa-io/up-go"
+)
+
+func Test_builders(t *testing.T) {
+	settled := DefaultTime.Add(2 * 24 * time.Hour)
+	tests := map[string]struct {
+		built  interface{}
+		decode func(b []byte) (interface{}, []up.DecodeIssue, error)
+		check  func(t *testing.T, built interface{})
+	}{
+		"transaction": {
+			built: NewTransaction().
+				ID("txn").
+				Description("Woolworths").
+				Amount(-12.50, "AUD").
+				ForeignAmount(-1100, "JPY").
+				RoundUp(-0.50).
+				CardPurchase(up.TransactionCardPurchaseMethodContactless, "0001").
+				Account("spending").
+				Category("groceries").
+				ParentCategory("home").
+				Tags("food", "weekly").
+				Settled(settled).
+				Build(),
+			decode: decodeWith[up.TransactionDataWrapper],
+			check: func(t *testing.T, built interface{}) {
+				txn := built.(up.TransactionDataWrapper)
+				if got, want := txn.Attributes.Amount, up.NewMoney("AUD", -1250); got != want {
+					t.Errorf("unexpected amount; want=%v, got=%v", want, got)
+				}
+				if got, want := txn.Attributes.ForeignAmount, up.NewMoney("JPY", -1100); got != want {
+					t.Errorf("unexpected foreign amount; want=%v, got=%v", want, got)
+				}
+				if txn.Attributes.Status != up.TransactionStatusSettled || !txn.Attributes.SettledAt.Equal(settled) {
+					t.Errorf("transaction isn't settled; got=%v at %v", txn.Attributes.Status, txn.Attributes.SettledAt)
+				}
+			},
+		},
+		"held transaction": {
+			built:  NewTransaction().Settled(settled).Held().TransferAccount("savings").Uncategorizable().Build(),
+			decode: decodeWith[up.TransactionDataWrapper],
+		},
+		"account": {
+			built:  NewAccount().ID("savings").DisplayName("Savings").Type(up.AccountTypeSaver).Joint().Balance(1234.56, "AUD").Build(),
+			decode: decodeWith[up.AccountDataWrapper],
+		},
+		"child category": {
+			built:  NewCategory("groceries").Name("Groceries").Parent("home").Build(),
+			decode: decodeWith[up.CategoryData],
+		},
+		"parent category": {
+			built:  NewCategory("home").Children("groceries", "utilities").Build(),
+			decode: decodeWith[up.CategoryData],
+		},
+		"tag": {
+			built:  NewTag("food").TransactionsLink("https://api.up.com.au/api/v1/transactions?filter%5Btag%5D=food").Build(),
+			decode: decodeWith[up.TagResource],
+		},
+		"webhook event": {
+			built:  NewWebhookEvent("webhook").Transaction(WebhookEventTypeTransactionCreated, "txn").Build(),
+			decode: decodeWith[WebhookEvent],
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(tt.built)
+			if err != nil {
+				t.Fatalf("failed to marshal built value; error=%v", err)
+			}
+			got, issues, err := tt.decode(b)
+			if err != nil {
+				t.Fatalf("failed to decode built value; error=%v", err)
+			}
+			if len(issues) > 0 {
+				t.Errorf("decoding built value found issues; issues=%v", issues)
+			}
+			if !reflect.DeepEqual(got, tt.built) {
+				t.Errorf("built value didn't round-trip;\nwant=%+v\ngot=%+v\n", tt.built, got)
+			}
+			if tt.check != nil {
+				tt.check(t, tt.built)
+			}
+		})
+	}
+}
+
+// decodeWith decodes the given payload into a T, checking it with
+// up.DiffSchema.
+func decodeWith[T any](b []byte) (interface{}, []up.DecodeIssue, error) {
+	var v T
+	issues, err := up.DiffSchema(b, &v)
+	return v, issues, err
+}
@@ -0,0 +1,78 @@
+package uptest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fault describes a fault the Server injects into the requests matching it.
+type Fault struct {
+	Method     string        // The method of the requests affected; empty for any.
+	Path       string        // The path prefix, after /api/v1, of the requests affected (eg. /transactions); empty for any.
+	Latency    time.Duration // How long to wait before responding.
+	StatusCode int           // The error status to respond with (eg. 429, 503); zero to respond normally.
+	RetryAfter time.Duration // The Retry-After header sent with the error status; zero to leave it out.
+	Times      int           // How many requests are affected; zero for every request.
+}
+
+// faultState tracks how many requests a Fault has affected.
+type faultState struct {
+	Fault
+	count int
+}
+
+// InjectFault injects the given fault into the requests matching it, until
+// it's affected Times requests or ClearFaults is called. Faults are checked
+// in the order they were injected, and only the first match is applied.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &faultState{Fault: f})
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// applyFault applies the first fault matching the request, and reports
+// whether it responded to the request.
+func (s *Server) applyFault(w http.ResponseWriter, r *http.Request) bool {
+	s.mu.Lock()
+	var f *faultState
+	for _, candidate := range s.faults {
+		switch {
+		case candidate.Times > 0 && candidate.count >= candidate.Times,
+			candidate.Method != "" && candidate.Method != r.Method,
+			!strings.HasPrefix(strings.TrimPrefix(r.URL.Path, "/api/v1"), candidate.Path):
+			continue
+		}
+		candidate.count++
+		f = candidate
+		break
+	}
+	s.mu.Unlock()
+	if f == nil {
+		return false
+	}
+
+	if f.Latency > 0 {
+		select {
+		case <-time.After(f.Latency):
+		case <-r.Context().Done():
+			return true
+		}
+	}
+	if f.StatusCode == 0 {
+		return false
+	}
+	if f.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(f.RetryAfter.Seconds())))
+	}
+	writeError(w, f.StatusCode, http.StatusText(f.StatusCode), "Injected by uptest.")
+	return true
+}
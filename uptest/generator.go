@@ -0,0 +1,442 @@
+package uptest
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jmpa-io/up-go"
+)
+
+// Dataset is a consistent set of resources, as returned by Generate: every
+// transaction belongs to one of the accounts, every transfer has a matching
+// transaction in the other account, every categorized transaction's category
+// is in the set, and each account's balance is the sum of its transactions
+// (after an opening balance).
+type Dataset struct {
+	Accounts     []up.AccountDataWrapper
+	Transactions []up.TransactionDataWrapper // Oldest first.
+	Categories   []up.CategoryData
+
+	// from Generate, so At can replay the dataset at another time.
+	opening   map[string]int64     // The opening balance of each account, in cents.
+	settledAt map[string]time.Time // When each transaction settles, even if after the end.
+}
+
+// At returns the Dataset as it was, or will be, at the given time: only the
+// transactions created by then, each held or settled as it was then, and each
+// account's balance then. Generate returns the Dataset at its end, where
+// recent transactions are still held; At a later time shows the same
+// transactions settled. For a Dataset not returned by Generate, the status of
+// transactions, and balances, are left as they are.
+func (d Dataset) At(t time.Time) Dataset {
+	at := Dataset{
+		Categories: slices.Clone(d.Categories),
+		opening:    d.opening,
+		settledAt:  d.settledAt,
+	}
+	balances := make(map[string]int64, len(d.opening))
+	for id, balance := range d.opening {
+		balances[id] = balance
+	}
+	for _, txn := range d.Transactions {
+		if txn.Attributes.CreatedAt.After(t) {
+			continue
+		}
+		if settled, ok := d.settledAt[txn.ID]; ok && settled.After(t) {
+			txn.Attributes.Status, txn.Attributes.SettledAt = up.TransactionStatusHeld, time.Time{}
+		} else if ok {
+			txn.Attributes.Status, txn.Attributes.SettledAt = up.TransactionStatusSettled, settled
+		}
+		balances[txn.Relationships.Account.Data.ID] += txn.Attributes.Amount.ValueInBaseUnits + txn.Attributes.RoundUp.Amount.ValueInBaseUnits
+		at.Transactions = append(at.Transactions, txn)
+	}
+	for _, a := range d.Accounts {
+		if d.opening != nil {
+			a.Attributes.Balance = up.NewMoney("AUD", balances[a.ID])
+		}
+		at.Accounts = append(at.Accounts, a)
+	}
+	return at
+}
+
+// WithDataset adds the resources in the given Dataset to the Server.
+func WithDataset(d Dataset) Option {
+	return func(s *Server) {
+		s.accounts = append(s.accounts, d.Accounts...)
+		s.transactions = append(s.transactions, d.Transactions...)
+		s.categories = append(s.categories, d.Categories...)
+	}
+}
+
+// GenerateOption configures the Dataset returned by Generate.
+type GenerateOption func(g *generator)
+
+// GenerateOptionMonths sets how many months of transactions are generated.
+// Defaults to 3.
+func GenerateOptionMonths(months int) GenerateOption {
+	return func(g *generator) {
+		g.months = months
+	}
+}
+
+// GenerateOptionEnd sets when the generated transactions end; transactions
+// created in the few days before it are still held. Defaults to DefaultTime.
+func GenerateOptionEnd(end time.Time) GenerateOption {
+	return func(g *generator) {
+		g.end = end
+	}
+}
+
+// GenerateOptionPurchasesPerMonth sets how many card purchases are generated
+// each month, across the transactional accounts. Defaults to 60.
+func GenerateOptionPurchasesPerMonth(purchases int) GenerateOption {
+	return func(g *generator) {
+		g.purchasesPerMonth = purchases
+	}
+}
+
+// ---
+
+// sydney is the time zone generated transactions are made in.
+var sydney = time.FixedZone("AEST", 10*60*60)
+
+// generatedCategories is the category tree, by parent, used by generated
+// transactions; a subset of the API's.
+var generatedCategories = []struct {
+	parent   string
+	children []string
+}{
+	{"good-life", []string{"booze", "pubs-and-bars", "restaurants-and-cafes", "takeaway"}},
+	{"home", []string{"groceries", "homeware-and-appliances", "utilities"}},
+	{"personal", []string{"clothing-and-accessories", "health-and-medical", "technology"}},
+	{"transport", []string{"fuel", "public-transport", "taxis-and-share-cars"}},
+}
+
+// merchant is a merchant generated card purchases are made at.
+type merchant struct {
+	description string
+	rawText     string
+	category    string
+	min, max    int64                            // The range of a purchase, in the currency's base units.
+	currency    string                           // The currency purchases are made in; empty for AUD.
+	method      up.TransactionCardPurchaseMethod // How purchases are made; empty for a tap.
+}
+
+var merchants = []merchant{
+	{"Woolworths", "WOOLWORTHS 1234 SYDNEY", "groceries", 1500, 22000, "", ""},
+	{"Coles", "COLES 0456 NEWTOWN", "groceries", 1200, 18000, "", ""},
+	{"Harris Farm Markets", "HARRIS FARM MARKETS BONDI", "groceries", 800, 9000, "", ""},
+	{"Guzman y Gomez", "GUZMAN Y GOMEZ SURRY HILLS", "takeaway", 1400, 3500, "", ""},
+	{"Uber Eats", "UBER *EATS HELP.UBER.COM", "takeaway", 2500, 6500, "", up.TransactionCardPurchaseMethodCardOnFile},
+	{"Single O", "SINGLE O SURRY HILLS", "restaurants-and-cafes", 450, 2800, "", ""},
+	{"The Grounds of Alexandria", "THE GROUNDS ALEXANDRIA", "restaurants-and-cafes", 3000, 12000, "", ""},
+	{"The Lord Gladstone", "LORD GLADSTONE CHIPPENDALE", "pubs-and-bars", 1100, 8000, "", ""},
+	{"Dan Murphy's", "DAN MURPHYS 5678 ALEXANDRIA", "booze", 2000, 9000, "", ""},
+	{"Ampol", "AMPOL FOODARY ROZELLE", "fuel", 4000, 11000, "", ""},
+	{"Transport for NSW", "TRANSPORTFORNSW OPAL", "public-transport", 300, 1800, "", ""},
+	{"Uber", "UBER *TRIP HELP.UBER.COM", "taxis-and-share-cars", 1200, 5500, "", up.TransactionCardPurchaseMethodCardOnFile},
+	{"Chemist Warehouse", "CHEMIST WAREHOUSE TOWN HALL", "health-and-medical", 600, 7500, "", ""},
+	{"Uniqlo", "UNIQLO PITT ST MALL", "clothing-and-accessories", 2000, 15000, "", ""},
+	{"JB Hi-Fi", "JB HI-FI BROADWAY", "technology", 3000, 30000, "", ""},
+	{"IKEA", "IKEA TEMPE", "homeware-and-appliances", 1500, 40000, "", ""},
+	{"AGL", "AGL SALES PTY LTD", "utilities", 9000, 30000, "", up.TransactionCardPurchaseMethodCardOnFile},
+	{"Amazon", "AMAZON.COM SEATTLE WA", "technology", 1000, 20000, "USD", up.TransactionCardPurchaseMethordEcommerce},
+	{"Steam", "STEAMGAMES.COM 4259522985", "technology", 500, 8000, "USD", up.TransactionCardPurchaseMethordEcommerce},
+	{"Muji", "MUJI SHIBUYA", "homeware-and-appliances", 1000, 9000, "JPY", up.TransactionCardPurchaseMethodCardOnFile},
+}
+
+// The rates, to AUD, of the foreign currencies merchants use.
+var audRates = map[string]float64{
+	"USD": 1.52,
+	"JPY": 0.0103,
+}
+
+// generator generates a Dataset.
+type generator struct {
+
+	// config.
+	months            int
+	end               time.Time
+	purchasesPerMonth int
+
+	// state.
+	rng          *rand.Rand
+	dataset      Dataset
+	transactions []*TransactionBuilder
+	opening      map[string]int64 // The opening balance of each account, in cents.
+}
+
+// the generated accounts.
+type generatedAccounts struct {
+	spending, saver, homeLoan, joint string
+}
+
+// Generate returns a Dataset of realistic, but fake, accounts and
+// transactions, as at the end; the same seed always generates the same
+// Dataset. There's a transactional, saver, home loan, and joint, account, with
+// months of card purchases (some foreign, with round-ups), refunds, salary,
+// interest, and transfers between the accounts. Transactions created in the
+// days before the end are held; use Dataset.At to see them settle.
+func Generate(seed uint64, options ...GenerateOption) Dataset {
+	g := &generator{
+		months:            3,
+		end:               DefaultTime,
+		purchasesPerMonth: 60,
+		rng:               rand.New(rand.NewPCG(seed, seed)),
+		opening:           make(map[string]int64),
+	}
+	for _, o := range options {
+		o(g)
+	}
+	start := g.end.AddDate(0, -g.months, 0)
+
+	// categories.
+	for _, c := range generatedCategories {
+		g.dataset.Categories = append(g.dataset.Categories,
+			NewCategory(c.parent).Name(categoryName(c.parent)).Children(c.children...).Build())
+		for _, child := range c.children {
+			g.dataset.Categories = append(g.dataset.Categories,
+				NewCategory(child).Name(categoryName(child)).Parent(c.parent).Build())
+		}
+	}
+
+	// accounts.
+	accounts := generatedAccounts{g.id(), g.id(), g.id(), g.id()}
+	opened := start.AddDate(-1, 0, 0)
+	for _, a := range []struct {
+		id      string
+		name    string
+		t       up.AccountType
+		joint   bool
+		opening int64
+	}{
+		{accounts.spending, "Spending", up.AccountTypeTransactional, false, 1_500_00},
+		{accounts.saver, "🏖️ Holiday", up.AccountTypeSaver, false, 5_000_00},
+		{accounts.homeLoan, "Home Loan", up.AccountTypeHomeLoan, false, -450_000_00},
+		{accounts.joint, "2Up Spending", up.AccountTypeTransactional, true, 800_00},
+	} {
+		b := NewAccount().ID(a.id).DisplayName(a.name).Type(a.t).CreatedAt(opened)
+		if a.joint {
+			b.Joint()
+		}
+		g.opening[a.id] = a.opening
+		g.dataset.Accounts = append(g.dataset.Accounts, b.Build())
+	}
+
+	// transactions.
+	for m := 0; m < g.months; m++ {
+		g.month(accounts, start.AddDate(0, m, 0))
+	}
+	g.dataset.opening = g.opening
+	g.dataset.settledAt = make(map[string]time.Time)
+	for _, b := range g.transactions {
+		if t := b.Build(); !t.Attributes.CreatedAt.After(g.end) {
+			g.dataset.Transactions = append(g.dataset.Transactions, t)
+			g.dataset.settledAt[t.ID] = t.Attributes.SettledAt
+		}
+	}
+	slices.SortStableFunc(g.dataset.Transactions, func(a, b up.TransactionDataWrapper) int {
+		return a.Attributes.CreatedAt.Compare(b.Attributes.CreatedAt)
+	})
+	return g.dataset.At(g.end)
+}
+
+// month generates the transactions for the month starting at the given time.
+func (g *generator) month(accounts generatedAccounts, start time.Time) {
+	days := start.AddDate(0, 1, 0).Sub(start).Hours() / 24
+
+	// salary, and the transfers made from it.
+	g.add(NewTransaction().
+		Account(accounts.spending).
+		Description("Acme Pty Ltd").
+		Message("Salary").
+		AmountInBaseUnits(9_000_00, "AUD").
+		Type(up.TransactionTypeSalary).
+		Uncategorizable())
+	g.settle(g.at(start, 0, 9), 0)
+	g.transfer(g.at(start, 1, 10), accounts.spending, accounts.homeLoan, 2_400_00, up.TransactionTypeScheduledTransfer)
+	g.transfer(g.at(start, 1, 10), accounts.spending, accounts.saver, 500_00, up.TransactionTypeScheduledTransfer)
+	g.transfer(g.at(start, 1, 11), accounts.spending, accounts.joint, 1_200_00, up.TransactionTypeTransfer)
+
+	// card purchases, across the month.
+	for i := 0; i < g.purchasesPerMonth; i++ {
+		account, customer := accounts.spending, "Alex"
+		if g.rng.IntN(5) == 0 {
+			account, customer = accounts.joint, []string{"Alex", "Sam"}[g.rng.IntN(2)]
+		}
+		g.purchase(accounts, account, customer, g.at(start, g.rng.IntN(int(days)), 7+g.rng.IntN(15)))
+	}
+
+	// interest, paid on the last day.
+	lastDay := g.at(start, int(days)-1, 23)
+	interest := g.balance(accounts.saver, lastDay) * 45 / 1000 / 12
+	g.add(NewTransaction().
+		Account(accounts.saver).
+		Description("Interest").
+		AmountInBaseUnits(interest, "AUD").
+		Type(up.TransactionTypeInterest).
+		Uncategorizable())
+	g.settle(lastDay, 0)
+}
+
+// purchase generates a card purchase in the given account, at a random
+// merchant, with its round-up and any refund.
+func (g *generator) purchase(accounts generatedAccounts, account, customer string, at time.Time) {
+	m := merchants[g.rng.IntN(len(merchants))]
+	units := m.min + g.rng.Int64N(m.max-m.min+1)
+	method, card := m.method, "1234"
+	if method == "" {
+		method = up.TransactionCardPurchaseMethodContactless
+	}
+	if account == accounts.joint {
+		card = "4321"
+	}
+	b := NewTransaction().
+		Account(account).
+		Description(m.description).
+		RawText(m.rawText).
+		Category(m.category).
+		ParentCategory(categoryParent(m.category)).
+		CardPurchase(method, card).
+		PerformingCustomer(customer)
+	cents := units
+	if m.currency != "" {
+		currency, _ := up.LookupCurrency(m.currency)
+		b.ForeignAmountInBaseUnits(-units, m.currency)
+		cents = int64(math.Round(float64(units) / math.Pow10(currency.Exponent) * audRates[m.currency] * 100))
+	}
+	b.AmountInBaseUnits(-cents, "AUD").HoldInfo()
+	if account == accounts.joint {
+		b.Tags("Shared")
+	} else if g.rng.IntN(10) == 0 {
+		b.Tags([]string{"Holiday", "Work", "Birthday"}[g.rng.IntN(3)])
+	}
+
+	// round-up, into the saver.
+	if roundUp := cents % 100; account == accounts.spending && m.currency == "" && roundUp != 0 {
+		b.RoundUpInBaseUnits(-(100 - roundUp))
+		g.add(b)
+		g.settle(at, 1+g.rng.IntN(3))
+		g.add(NewTransaction().
+			Account(accounts.saver).
+			TransferAccount(accounts.spending).
+			Description("Round Up").
+			AmountInBaseUnits(100-roundUp, "AUD").
+			Type(up.TransactionTypeRoundUp).
+			Uncategorizable())
+		g.settle(at, 0)
+	} else {
+		g.add(b)
+		g.settle(at, 1+g.rng.IntN(3))
+	}
+
+	// a refund, every so often.
+	if g.rng.IntN(30) == 0 {
+		refund := NewTransaction().
+			Account(account).
+			Description(m.description).
+			RawText(m.rawText).
+			Category(m.category).
+			ParentCategory(categoryParent(m.category)).
+			AmountInBaseUnits(cents, "AUD").
+			Type(up.TransactionTypeRefund)
+		g.add(refund)
+		g.settle(at.AddDate(0, 0, 3+g.rng.IntN(5)), 1)
+	}
+}
+
+// transfer generates a transfer of the given cents between two accounts.
+func (g *generator) transfer(at time.Time, from, to string, cents int64, t up.TransactionType) {
+	g.add(NewTransaction().
+		Account(from).
+		TransferAccount(to).
+		Description("Transfer to "+g.accountName(to)).
+		AmountInBaseUnits(-cents, "AUD").
+		Type(t).
+		Uncategorizable())
+	g.settle(at, 0)
+	g.add(NewTransaction().
+		Account(to).
+		TransferAccount(from).
+		Description("Transfer from "+g.accountName(from)).
+		AmountInBaseUnits(cents, "AUD").
+		Type(t).
+		Uncategorizable())
+	g.settle(at, 0)
+}
+
+// balance returns the balance, in cents, of the given account at the given
+// time; including any round-ups taken from it.
+func (g *generator) balance(account string, at time.Time) int64 {
+	balance := g.opening[account]
+	for _, b := range g.transactions {
+		if b.t.Relationships.Account.Data.ID != account || b.t.Attributes.CreatedAt.After(at) {
+			continue
+		}
+		balance += b.t.Attributes.Amount.ValueInBaseUnits + b.t.Attributes.RoundUp.Amount.ValueInBaseUnits
+	}
+	return balance
+}
+
+// add adds the given transaction, with a generated ID.
+func (g *generator) add(b *TransactionBuilder) {
+	g.transactions = append(g.transactions, b.ID(g.id()))
+}
+
+// settle sets when the last added transaction was created, and settles it
+// the given number of days later; even if that's after the end, so Dataset.At
+// can show it settling.
+func (g *generator) settle(at time.Time, days int) {
+	g.transactions[len(g.transactions)-1].CreatedAt(at).Settled(at.AddDate(0, 0, days))
+}
+
+// at returns the given hour, in Sydney, of the given day after start.
+func (g *generator) at(start time.Time, day, hour int) time.Time {
+	local := start.In(sydney)
+	t := time.Date(local.Year(), local.Month(), local.Day()+day, hour, g.rng.IntN(60), g.rng.IntN(60), 0, sydney)
+	return t.UTC()
+}
+
+// id returns a new, random, UUID-shaped ID.
+func (g *generator) id() string {
+	return fmt.Sprintf("%08x-%04x-4%03x-8%03x-%012x",
+		g.rng.Uint32(), g.rng.Uint32()&0xffff, g.rng.Uint32()&0xfff, g.rng.Uint32()&0xfff, g.rng.Uint64()&0xffffffffffff)
+}
+
+// accountName returns the display name of the given account.
+func (g *generator) accountName(id string) string {
+	for _, a := range g.dataset.Accounts {
+		if a.ID == id {
+			return a.Attributes.DisplayName
+		}
+	}
+	return id
+}
+
+// categoryParent returns the parent of the given generated category.
+func categoryParent(id string) string {
+	for _, c := range generatedCategories {
+		if slices.Contains(c.children, id) {
+			return c.parent
+		}
+	}
+	return ""
+}
+
+// categoryName returns the display name of the given category (eg.
+// "restaurants-and-cafes" is "Restaurants & Cafes").
+func categoryName(id string) string {
+	words := strings.Split(id, "-")
+	for i, w := range words {
+		if w == "and" {
+			words[i] = "&"
+			continue
+		}
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,132 @@
+package uptest
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/jmpa-io/up-go"
+)
+
+func Test_Generate(t *testing.T) {
+	d := Generate(1)
+
+	// the same seed generates the same dataset.
+	if !reflect.DeepEqual(d, Generate(1)) {
+		t.Errorf("Generate() returned different datasets for the same seed")
+	}
+	if reflect.DeepEqual(d, Generate(2)) {
+		t.Errorf("Generate() returned the same dataset for different seeds")
+	}
+
+	// the dataset is consistent.
+	accounts := make(map[string]up.AccountDataWrapper)
+	var types []string
+	for _, a := range d.Accounts {
+		accounts[a.ID] = a
+		types = append(types, string(a.Attributes.AccountType)+"/"+string(a.Attributes.OwnershipType))
+	}
+	if want := []string{"TRANSACTIONAL/INDIVIDUAL", "SAVER/INDIVIDUAL", "HOME_LOAN/INDIVIDUAL", "TRANSACTIONAL/JOINT"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("Generate() returned unexpected accounts; want=%v, got=%v", want, types)
+	}
+	parents := make(map[string]string)
+	for _, c := range d.Categories {
+		if c.Relationships.Parent.Data != nil {
+			parents[c.ID] = c.Relationships.Parent.Data.ID
+		}
+	}
+	counts := make(map[string]int)
+	for i, txn := range d.Transactions {
+		a, rel := txn.Attributes, txn.Relationships
+		if i > 0 && a.CreatedAt.Before(d.Transactions[i-1].Attributes.CreatedAt) {
+			t.Errorf("transaction %s is out of order", txn.ID)
+		}
+		if _, ok := accounts[rel.Account.Data.ID]; !ok {
+			t.Errorf("transaction %s belongs to an unknown account %s", txn.ID, rel.Account.Data.ID)
+		}
+		if category := rel.Category.Data.ID; category != "" && parents[category] != rel.ParentCategory.Data.ID {
+			t.Errorf("transaction %s has an inconsistent category %s/%s", txn.ID, rel.ParentCategory.Data.ID, category)
+		}
+		if a.CreatedAt.After(DefaultTime) {
+			t.Errorf("transaction %s was created after the end", txn.ID)
+		}
+		if transfer := rel.TransferAccount.Data.ID; transfer != "" && a.TransactionType != up.TransactionTypeRoundUp {
+			var matched bool
+			for _, other := range d.Transactions {
+				matched = matched || (other.Relationships.Account.Data.ID == transfer &&
+					other.Relationships.TransferAccount.Data.ID == rel.Account.Data.ID &&
+					other.Attributes.Amount == a.Amount.Neg() &&
+					other.Attributes.CreatedAt.Equal(a.CreatedAt))
+			}
+			if !matched {
+				t.Errorf("transfer %s has no matching transaction in %s", txn.ID, transfer)
+			}
+		}
+		counts[string(a.Status)]++
+		counts[string(a.TransactionType)]++
+	}
+	for _, kind := range []string{"HELD", "SETTLED", "Purchase", "International Purchase", "Round Up", "Salary", "Interest", "Scheduled Transfer"} {
+		if counts[kind] == 0 {
+			t.Errorf("Generate() didn't generate any %s transactions", kind)
+		}
+	}
+
+	// balances are the sum of each account's transactions.
+	balances := make(map[string]int64)
+	for id, opening := range d.opening {
+		balances[id] = opening
+	}
+	for _, txn := range d.Transactions {
+		balances[txn.Relationships.Account.Data.ID] += txn.Attributes.Amount.ValueInBaseUnits + txn.Attributes.RoundUp.Amount.ValueInBaseUnits
+	}
+	for _, a := range d.Accounts {
+		if a.Attributes.Balance.ValueInBaseUnits != balances[a.ID] {
+			t.Errorf("account %s has an inconsistent balance; want=%v, got=%v", a.ID, balances[a.ID], a.Attributes.Balance.ValueInBaseUnits)
+		}
+	}
+
+	// held transactions settle later, and earlier ones were held.
+	if !reflect.DeepEqual(d.At(DefaultTime), d) {
+		t.Errorf("At() the end returned a different dataset")
+	}
+	later := d.At(DefaultTime.AddDate(0, 0, 7))
+	for i, txn := range d.Transactions {
+		if txn.Attributes.Status == up.TransactionStatusHeld && later.Transactions[i].Attributes.Status != up.TransactionStatusSettled {
+			t.Errorf("transaction %s didn't settle after the end", txn.ID)
+		}
+	}
+	earlier := d.At(DefaultTime.AddDate(0, -1, 0))
+	var held int
+	for _, txn := range earlier.Transactions {
+		if txn.Attributes.Status == up.TransactionStatusHeld {
+			held++
+		}
+	}
+	if held == 0 || len(earlier.Transactions) >= len(d.Transactions) {
+		t.Errorf("At() a month before the end returned unexpected transactions; held=%d, transactions=%d", held, len(earlier.Transactions))
+	}
+
+	// the dataset decodes cleanly, and can be served.
+	b, err := json.Marshal(up.WrapperSlice[up.TransactionDataWrapper]{Data: d.Transactions})
+	if err != nil {
+		t.Fatalf("failed to marshal transactions; error=%v", err)
+	}
+	var decoded up.WrapperSlice[up.TransactionDataWrapper]
+	if issues, err := up.DiffSchema(b, &decoded); err != nil || len(issues) > 0 {
+		t.Errorf("decoding transactions found issues; issues=%v, error=%v", issues, err)
+	}
+	s := NewServer(WithDataset(d))
+	defer s.Close()
+	c, err := s.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("NewClient() returned an error; error=%v", err)
+	}
+	txns, err := c.ListTransactions(context.Background(), up.ListTransactionsOptionPageSize(100))
+	if err != nil {
+		t.Fatalf("ListTransactions() returned an error; error=%v", err)
+	}
+	if len(txns) != len(d.Transactions) {
+		t.Errorf("ListTransactions() returned %d transactions; want=%d", len(txns), len(d.Transactions))
+	}
+}
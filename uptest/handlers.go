@@ -0,0 +1,555 @@
+package uptest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jmpa-io/up-go"
+)
+
+// The default and maximum page sizes, as used by the API.
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// apiError is a single error in a JSON:API error body.
+type apiError struct {
+	Status string `json:"status"`
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// writeError writes a JSON:API error body with the given status.
+func writeError(w http.ResponseWriter, status int, title, detail string) {
+	writeJSON(w, status, map[string][]apiError{
+		"errors": {{strconv.Itoa(status), title, detail}},
+	})
+}
+
+// writeJSON writes the given value as a JSON body with the given status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// newID returns a new, unique, ID for a resource; the caller must hold s.mu.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+}
+
+// paginate writes the page of items requested by the page[size] and
+// page[after] query parameters, with a link to the next page, if any.
+func paginate[T any](w http.ResponseWriter, r *http.Request, endpoint string, items []T, id func(T) string) {
+	q := r.URL.Query()
+	size := defaultPageSize
+	if v := q.Get("page[size]"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			writeError(w, http.StatusBadRequest, "Invalid Parameter",
+				fmt.Sprintf("page[size] must be between 1 and %d.", maxPageSize))
+			return
+		}
+		size = n
+	}
+	start := 0
+	if after := q.Get("page[after]"); after != "" {
+		i := slices.IndexFunc(items, func(t T) bool { return id(t) == after })
+		if i == -1 {
+			writeError(w, http.StatusBadRequest, "Invalid Parameter", "page[after] is not a valid cursor.")
+			return
+		}
+		start = i + 1
+	}
+	end := min(start+size, len(items))
+	resp := up.WrapperSlice[T]{Data: items[start:end]}
+	if resp.Data == nil {
+		resp.Data = []T{}
+	}
+	if end < len(items) {
+		q.Set("page[after]", id(items[end-1]))
+		resp.Links.Next = endpoint + r.URL.Path[len("/api/v1"):] + "?" + q.Encode()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// filterTime parses the given RFC 3339 filter, writing an error if it's
+// invalid.
+func filterTime(w http.ResponseWriter, q url.Values, name string) (t time.Time, ok bool) {
+	v := q.Get(name)
+	if v == "" {
+		return time.Time{}, true
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid Parameter", name+" must be an RFC 3339 timestamp.")
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// ---
+
+func (s *Server) ping(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, up.Ping{Meta: up.PingMeta{
+		ID:          "00000000-0000-4000-8000-000000000000",
+		StatusEmoji: "⚡️",
+	}})
+}
+
+// account returns the account with the given ID, with its links set; the
+// caller must hold s.mu.
+func (s *Server) account(id string) (up.AccountDataWrapper, bool) {
+	i := slices.IndexFunc(s.accounts, func(a up.AccountDataWrapper) bool { return a.ID == id })
+	if i == -1 {
+		return up.AccountDataWrapper{}, false
+	}
+	a := s.accounts[i]
+	a.Type = "accounts"
+	a.Links.Self = s.Endpoint() + "/accounts/" + a.ID
+	a.Relationships.Transactions.Links.Related = s.Endpoint() + "/accounts/" + a.ID + "/transactions"
+	return a, true
+}
+
+func (s *Server) listAccounts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	var accounts []up.AccountDataWrapper
+	for _, a := range s.accounts {
+		a, _ = s.account(a.ID)
+		switch {
+		case q.Has("filter[accountType]") && q.Get("filter[accountType]") != string(a.Attributes.AccountType),
+			q.Has("filter[ownershipType]") && q.Get("filter[ownershipType]") != string(a.Attributes.OwnershipType):
+			continue
+		}
+		accounts = append(accounts, a)
+	}
+	s.mu.Unlock()
+	paginate(w, r, s.Endpoint(), accounts, func(a up.AccountDataWrapper) string { return a.ID })
+}
+
+func (s *Server) getAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	a, ok := s.account(r.PathValue("id"))
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found", "The account could not be found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, up.Wrapper[up.AccountDataWrapper]{Data: a})
+}
+
+// ---
+
+// transaction returns the index of the transaction with the given ID; the
+// caller must hold s.mu.
+func (s *Server) transaction(id string) int {
+	return slices.IndexFunc(s.transactions, func(t up.TransactionDataWrapper) bool { return t.ID == id })
+}
+
+// withLinks returns the given transaction with its links set.
+func (s *Server) withLinks(t up.TransactionDataWrapper) up.TransactionDataWrapper {
+	t.Type = "transactions"
+	t.Links.Self = s.Endpoint() + "/transactions/" + t.ID
+	t.Relationships.Tags.Links.Self = s.Endpoint() + "/transactions/" + t.ID + "/relationships/tags"
+	return t
+}
+
+// writeTransactions writes the transactions matching the request's filters,
+// and given account (if not empty), newest first.
+func (s *Server) writeTransactions(w http.ResponseWriter, r *http.Request, accountID string) {
+	q := r.URL.Query()
+	since, ok := filterTime(w, q, "filter[since]")
+	if !ok {
+		return
+	}
+	until, ok := filterTime(w, q, "filter[until]")
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	var txns []up.TransactionDataWrapper
+	for _, t := range s.transactions {
+		a, rel := t.Attributes, t.Relationships
+		category := q.Get("filter[category]")
+		switch {
+		case accountID != "" && rel.Account.Data.ID != accountID,
+			q.Has("filter[status]") && q.Get("filter[status]") != string(a.Status),
+			!since.IsZero() && a.CreatedAt.Before(since),
+			!until.IsZero() && !a.CreatedAt.Before(until),
+			category != "" && rel.Category.Data.ID != category && rel.ParentCategory.Data.ID != category,
+			q.Has("filter[tag]") && !slices.ContainsFunc(rel.Tags.Data, func(o up.Object) bool {
+				return o.ID == q.Get("filter[tag]")
+			}):
+			continue
+		}
+		txns = append(txns, s.withLinks(t))
+	}
+	s.mu.Unlock()
+	slices.SortStableFunc(txns, func(a, b up.TransactionDataWrapper) int {
+		return b.Attributes.CreatedAt.Compare(a.Attributes.CreatedAt)
+	})
+	paginate(w, r, s.Endpoint(), txns, func(t up.TransactionDataWrapper) string { return t.ID })
+}
+
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request) {
+	s.writeTransactions(w, r, "")
+}
+
+func (s *Server) listAccountTransactions(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	_, ok := s.account(r.PathValue("id"))
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found", "The account could not be found.")
+		return
+	}
+	s.writeTransactions(w, r, r.PathValue("id"))
+}
+
+func (s *Server) getTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	i := s.transaction(r.PathValue("id"))
+	var t up.TransactionDataWrapper
+	if i != -1 {
+		t = s.withLinks(s.transactions[i])
+	}
+	s.mu.Unlock()
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Not Found", "The transaction could not be found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, up.Wrapper[up.TransactionDataWrapper]{Data: t})
+}
+
+// changeTags applies the tags in the request body to a transaction, using the
+// given function to work out its new tags.
+func (s *Server) changeTags(w http.ResponseWriter, r *http.Request, change func(current, tags []up.Object) []up.Object) {
+	var body struct {
+		Data []up.Object `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid Request", "The request body is not valid JSON.")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.transaction(r.PathValue("id"))
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Not Found", "The transaction could not be found.")
+		return
+	}
+	tags := change(s.transactions[i].Relationships.Tags.Data, body.Data)
+	if len(tags) > up.MaxTagsPerTransaction {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Request",
+			fmt.Sprintf("A transaction can have at most %d tags.", up.MaxTagsPerTransaction))
+		return
+	}
+	s.transactions[i].Relationships.Tags.Data = tags
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) addTags(w http.ResponseWriter, r *http.Request) {
+	s.changeTags(w, r, func(current, tags []up.Object) []up.Object {
+		current = slices.Clone(current)
+		for _, t := range tags {
+			if !slices.ContainsFunc(current, func(o up.Object) bool { return o.ID == t.ID }) {
+				current = append(current, up.Object{Type: "tags", ID: t.ID})
+			}
+		}
+		return current
+	})
+}
+
+func (s *Server) removeTags(w http.ResponseWriter, r *http.Request) {
+	s.changeTags(w, r, func(current, tags []up.Object) []up.Object {
+		return slices.DeleteFunc(slices.Clone(current), func(o up.Object) bool {
+			return slices.ContainsFunc(tags, func(t up.Object) bool { return t.ID == o.ID })
+		})
+	})
+}
+
+func (s *Server) setCategory(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Data *up.Object `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid Request", "The request body is not valid JSON.")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.transaction(r.PathValue("id"))
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Not Found", "The transaction could not be found.")
+		return
+	}
+	t := &s.transactions[i]
+	if !t.Attributes.IsCategorizable {
+		writeError(w, http.StatusForbidden, "Forbidden", "The transaction can't be categorized.")
+		return
+	}
+	if body.Data == nil {
+		t.Relationships.Category.Data, t.Relationships.ParentCategory.Data = up.Object{}, up.Object{}
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	c, ok := s.category(body.Data.ID)
+	if !ok || c.Relationships.Parent.Data == nil {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Request", "The category must be an existing child category.")
+		return
+	}
+	t.Relationships.Category.Data = up.Object{Type: "categories", ID: c.ID}
+	t.Relationships.ParentCategory.Data = *c.Relationships.Parent.Data
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ---
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	seen := make(map[string]bool)
+	for _, t := range s.transactions {
+		for _, tag := range t.Relationships.Tags.Data {
+			seen[tag.ID] = true
+		}
+	}
+	s.mu.Unlock()
+	var tags []up.TagResource
+	for id := range seen {
+		tag := up.TagResource{Object: up.Object{Type: "tags", ID: id}}
+		tag.Relationships.Transactions.Links.Related = s.Endpoint() + "/transactions?" +
+			url.Values{"filter[tag]": {id}}.Encode()
+		tags = append(tags, tag)
+	}
+	slices.SortFunc(tags, func(a, b up.TagResource) int { return cmp.Compare(a.ID, b.ID) })
+	paginate(w, r, s.Endpoint(), tags, func(t up.TagResource) string { return t.ID })
+}
+
+// ---
+
+// category returns the category with the given ID, with its links set; the
+// caller must hold s.mu.
+func (s *Server) category(id string) (up.CategoryData, bool) {
+	i := slices.IndexFunc(s.categories, func(c up.CategoryData) bool { return c.ID == id })
+	if i == -1 {
+		return up.CategoryData{}, false
+	}
+	c := s.categories[i]
+	c.Type = "categories"
+	c.Links.Self = s.Endpoint() + "/categories/" + c.ID
+	return c, true
+}
+
+func (s *Server) listCategories(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if parent := q.Get("filter[parent]"); parent != "" {
+		if _, ok := s.category(parent); !ok {
+			writeError(w, http.StatusNotFound, "Not Found", "The parent category could not be found.")
+			return
+		}
+	}
+	categories := []up.CategoryData{}
+	for _, c := range s.categories {
+		c, _ = s.category(c.ID)
+		parent := c.Relationships.Parent.Data
+		if q.Has("filter[parent]") && (parent == nil || parent.ID != q.Get("filter[parent]")) {
+			continue
+		}
+		categories = append(categories, c)
+	}
+	writeJSON(w, http.StatusOK, up.CategoryPaginationWrapper{Data: categories})
+}
+
+func (s *Server) getCategory(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	c, ok := s.category(r.PathValue("id"))
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found", "The category could not be found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, up.Wrapper[up.CategoryData]{Data: c})
+}
+
+// ---
+
+// attachmentResource is the API's representation of an Attachment.
+type attachmentResource struct {
+	up.Object
+	Attributes struct {
+		CreatedAt       time.Time `json:"createdAt"`
+		FileURL         string    `json:"fileURL"`
+		FileExtension   string    `json:"fileExtension"`
+		FileContentType string    `json:"fileContentType"`
+	} `json:"attributes"`
+	Relationships struct {
+		Transaction up.Wrapper[up.Object] `json:"transaction"`
+	} `json:"relationships"`
+	Links up.Links `json:"links"`
+}
+
+// attachmentResource returns the API's representation of the given
+// attachment.
+func (s *Server) attachmentResource(a Attachment) attachmentResource {
+	var res attachmentResource
+	res.Object = up.Object{Type: "attachments", ID: a.ID}
+	res.Attributes.CreatedAt = a.CreatedAt
+	res.Attributes.FileURL = a.FileURL
+	res.Attributes.FileExtension = a.FileExtension
+	res.Attributes.FileContentType = a.FileContentType
+	res.Relationships.Transaction.Data = up.Object{Type: "transactions", ID: a.TransactionID}
+	res.Relationships.Transaction.Links.Related = s.Endpoint() + "/transactions/" + a.TransactionID
+	res.Links.Self = s.Endpoint() + "/attachments/" + a.ID
+	return res
+}
+
+func (s *Server) listAttachments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	var attachments []attachmentResource
+	for _, a := range s.attachments {
+		attachments = append(attachments, s.attachmentResource(a))
+	}
+	s.mu.Unlock()
+	paginate(w, r, s.Endpoint(), attachments, func(a attachmentResource) string { return a.ID })
+}
+
+func (s *Server) getAttachment(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.attachments, func(a Attachment) bool { return a.ID == r.PathValue("id") })
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Not Found", "The attachment could not be found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, up.Wrapper[attachmentResource]{Data: s.attachmentResource(s.attachments[i])})
+}
+
+// ---
+
+// webhookResource is the API's representation of a Webhook.
+type webhookResource struct {
+	up.Object
+	Attributes struct {
+		URL         string    `json:"url"`
+		Description string    `json:"description"`
+		SecretKey   string    `json:"secretKey,omitempty"` // Only returned when the webhook is created.
+		CreatedAt   time.Time `json:"createdAt"`
+	} `json:"attributes"`
+	Relationships struct {
+		Logs up.WrapperOmittable `json:"logs"`
+	} `json:"relationships"`
+	Links up.Links `json:"links"`
+}
+
+// webhookResource returns the API's representation of the given webhook.
+func (s *Server) webhookResource(wh Webhook, withSecret bool) webhookResource {
+	var res webhookResource
+	res.Object = up.Object{Type: "webhooks", ID: wh.ID}
+	res.Attributes.URL = wh.URL
+	res.Attributes.Description = wh.Description
+	res.Attributes.CreatedAt = wh.CreatedAt
+	if withSecret {
+		res.Attributes.SecretKey = wh.SecretKey
+	}
+	res.Relationships.Logs.Links.Related = s.Endpoint() + "/webhooks/" + wh.ID + "/logs"
+	res.Links.Self = s.Endpoint() + "/webhooks/" + wh.ID
+	return res
+}
+
+// webhook returns the index of the webhook with the given ID; the caller must
+// hold s.mu.
+func (s *Server) webhook(id string) int {
+	return slices.IndexFunc(s.webhooks, func(wh Webhook) bool { return wh.ID == id })
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	var webhooks []webhookResource
+	for _, wh := range s.webhooks {
+		webhooks = append(webhooks, s.webhookResource(wh, false))
+	}
+	s.mu.Unlock()
+	paginate(w, r, s.Endpoint(), webhooks, func(wh webhookResource) string { return wh.ID })
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Data struct {
+			Attributes struct {
+				URL         string `json:"url"`
+				Description string `json:"description"`
+			} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid Request", "The request body is not valid JSON.")
+		return
+	}
+	attrs := body.Data.Attributes
+	if u, err := url.Parse(attrs.URL); err != nil || !u.IsAbs() {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid Attribute", "url must be an absolute URL.")
+		return
+	}
+	s.mu.Lock()
+	wh := Webhook{
+		ID:          s.newID(),
+		URL:         attrs.URL,
+		Description: attrs.Description,
+		CreatedAt:   time.Now(),
+	}
+	wh.SecretKey = "secret-" + wh.ID
+	s.webhooks = append(s.webhooks, wh)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, up.Wrapper[webhookResource]{Data: s.webhookResource(wh, true)})
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.webhook(r.PathValue("id"))
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Not Found", "The webhook could not be found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, up.Wrapper[webhookResource]{Data: s.webhookResource(s.webhooks[i], false)})
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.webhook(r.PathValue("id"))
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Not Found", "The webhook could not be found.")
+		return
+	}
+	s.webhooks = slices.Delete(s.webhooks, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) pingWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.webhook(r.PathValue("id"))
+	if i == -1 {
+		writeError(w, http.StatusNotFound, "Not Found", "The webhook could not be found.")
+		return
+	}
+	var event WebhookEvent
+	event.Object = up.Object{Type: "webhook-events", ID: s.newID()}
+	event.Attributes.EventType = WebhookEventTypePing
+	event.Attributes.CreatedAt = time.Now()
+	event.Relationships.Webhook.Data = up.Object{Type: "webhooks", ID: s.webhooks[i].ID}
+	event.Relationships.Webhook.Links.Related = s.Endpoint() + "/webhooks/" + s.webhooks[i].ID
+	writeJSON(w, http.StatusCreated, up.Wrapper[WebhookEvent]{Data: event})
+}
@@ -0,0 +1,253 @@
+// Package uptest provides an in-process fake of the Up Bank API, for testing
+// code that uses github.com/jmpa-io/up-go against realistic behavior - mutable
+// state, pagination, filters, JSON:API error bodies and injected faults -
+// rather than static responses.
+package uptest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmpa-io/up-go"
+)
+
+// DefaultToken is the token the Server accepts unless WithToken is used.
+const DefaultToken = "up:yeah:uptest"
+
+// Attachment is an attachment (eg. a receipt) held by the Server. The API
+// returns these, but the up package doesn't have a type for them yet.
+type Attachment struct {
+	ID              string
+	TransactionID   string
+	CreatedAt       time.Time
+	FileURL         string
+	FileExtension   string
+	FileContentType string
+}
+
+// Webhook is a webhook held by the Server. Events sent to webhooks (eg. by
+// pinging them) aren't delivered.
+type Webhook struct {
+	ID          string
+	URL         string
+	Description string
+	SecretKey   string
+	CreatedAt   time.Time
+}
+
+// WebhookEventType is the type of a WebhookEvent.
+type WebhookEventType string
+
+const (
+	WebhookEventTypeTransactionCreated WebhookEventType = "TRANSACTION_CREATED" // A transaction was created.
+	WebhookEventTypeTransactionSettled WebhookEventType = "TRANSACTION_SETTLED" // A transaction was settled.
+	WebhookEventTypeTransactionDeleted WebhookEventType = "TRANSACTION_DELETED" // A held transaction was deleted.
+	WebhookEventTypePing               WebhookEventType = "PING"                // A webhook was pinged.
+)
+
+// WebhookEventAttributes defines the core details of a WebhookEvent.
+type WebhookEventAttributes struct {
+	EventType WebhookEventType `json:"eventType"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// WebhookEventRelationships defines the relationships to other resources for
+// a WebhookEvent.
+type WebhookEventRelationships struct {
+	Webhook     up.Wrapper[up.Object]  `json:"webhook"`
+	Transaction *up.Wrapper[up.Object] `json:"transaction,omitempty"` // Only sent for transaction events.
+}
+
+// WebhookEvent is an event sent to a webhook, as returned when pinging one.
+// The up package doesn't have a type for these yet.
+type WebhookEvent struct {
+	up.Object
+	Attributes    WebhookEventAttributes    `json:"attributes"`
+	Relationships WebhookEventRelationships `json:"relationships"`
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithToken sets the token the Server accepts. Defaults to DefaultToken.
+func WithToken(token string) Option {
+	return func(s *Server) {
+		s.token = token
+	}
+}
+
+// WithAccounts adds the given accounts to the Server.
+func WithAccounts(accounts ...up.AccountDataWrapper) Option {
+	return func(s *Server) {
+		s.accounts = append(s.accounts, accounts...)
+	}
+}
+
+// WithTransactions adds the given transactions to the Server.
+func WithTransactions(transactions ...up.TransactionDataWrapper) Option {
+	return func(s *Server) {
+		s.transactions = append(s.transactions, transactions...)
+	}
+}
+
+// WithCategories adds the given categories to the Server.
+func WithCategories(categories ...up.CategoryData) Option {
+	return func(s *Server) {
+		s.categories = append(s.categories, categories...)
+	}
+}
+
+// WithAttachments adds the given attachments to the Server.
+func WithAttachments(attachments ...Attachment) Option {
+	return func(s *Server) {
+		s.attachments = append(s.attachments, attachments...)
+	}
+}
+
+// WithWebhooks adds the given webhooks to the Server.
+func WithWebhooks(webhooks ...Webhook) Option {
+	return func(s *Server) {
+		s.webhooks = append(s.webhooks, webhooks...)
+	}
+}
+
+// Server is a fake of the Up Bank API, running on a local httptest.Server. Its
+// state can be changed through the API (eg. tagging transactions), or
+// directly, and is safe to use concurrently.
+type Server struct {
+	*httptest.Server
+
+	// config.
+	token string // The token requests must be authenticated with.
+
+	// state.
+	mu           sync.Mutex
+	accounts     []up.AccountDataWrapper
+	transactions []up.TransactionDataWrapper
+	categories   []up.CategoryData
+	attachments  []Attachment
+	webhooks     []Webhook
+	faults       []*faultState
+	nextID       int
+}
+
+// NewServer starts and returns a new Server, which should be closed once
+// finished with.
+func NewServer(options ...Option) *Server {
+	s := &Server{token: DefaultToken}
+	for _, o := range options {
+		o(s)
+	}
+	s.Server = httptest.NewServer(s.routes())
+	return s
+}
+
+// Endpoint returns the endpoint of the Server's API, for up.WithEndpoint.
+func (s *Server) Endpoint() string {
+	return s.URL + "/api/v1"
+}
+
+// NewClient returns a client for the Server, authenticated with its token.
+// Any given options are applied after those pointing the client at the
+// Server.
+func (s *Server) NewClient(ctx context.Context, options ...up.Option) (*up.Client, error) {
+	return up.New(ctx, s.token, append([]up.Option{
+		up.WithEndpoint(s.Endpoint()),
+		up.WithHttpClient(s.Client()),
+	}, options...)...)
+}
+
+// AddAccounts adds the given accounts to the Server.
+func (s *Server) AddAccounts(accounts ...up.AccountDataWrapper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts = append(s.accounts, accounts...)
+}
+
+// AddTransactions adds the given transactions to the Server.
+func (s *Server) AddTransactions(transactions ...up.TransactionDataWrapper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.transactions = append(s.transactions, transactions...)
+}
+
+// AddCategories adds the given categories to the Server.
+func (s *Server) AddCategories(categories ...up.CategoryData) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.categories = append(s.categories, categories...)
+}
+
+// AddAttachments adds the given attachments to the Server.
+func (s *Server) AddAttachments(attachments ...Attachment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attachments = append(s.attachments, attachments...)
+}
+
+// Transaction returns the Server's current state of a transaction, so tests
+// can check changes made through the API.
+func (s *Server) Transaction(id string) (up.TransactionDataWrapper, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.transactions, func(t up.TransactionDataWrapper) bool { return t.ID == id })
+	if i == -1 {
+		return up.TransactionDataWrapper{}, false
+	}
+	return s.transactions[i], true
+}
+
+// Webhooks returns the Server's current webhooks.
+func (s *Server) Webhooks() []Webhook {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.webhooks)
+}
+
+// routes returns the handler for every endpoint of the API the Server fakes.
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	for pattern, h := range map[string]http.HandlerFunc{
+		"GET /util/ping":                                  s.ping,
+		"GET /accounts":                                   s.listAccounts,
+		"GET /accounts/{id}":                              s.getAccount,
+		"GET /accounts/{id}/transactions":                 s.listAccountTransactions,
+		"GET /transactions":                               s.listTransactions,
+		"GET /transactions/{id}":                          s.getTransaction,
+		"POST /transactions/{id}/relationships/tags":      s.addTags,
+		"DELETE /transactions/{id}/relationships/tags":    s.removeTags,
+		"PATCH /transactions/{id}/relationships/category": s.setCategory,
+		"GET /tags":                                       s.listTags,
+		"GET /categories":                                 s.listCategories,
+		"GET /categories/{id}":                            s.getCategory,
+		"GET /attachments":                                s.listAttachments,
+		"GET /attachments/{id}":                           s.getAttachment,
+		"GET /webhooks":                                   s.listWebhooks,
+		"POST /webhooks":                                  s.createWebhook,
+		"GET /webhooks/{id}":                              s.getWebhook,
+		"DELETE /webhooks/{id}":                           s.deleteWebhook,
+		"POST /webhooks/{id}/ping":                        s.pingWebhook,
+	} {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" /api/v1"+path, h)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "Not Found", "The requested endpoint doesn't exist.")
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.token {
+			writeError(w, http.StatusUnauthorized, "Not Authorized",
+				"The request was not authenticated because no valid credential was found in the Authorization header, or the Authorization header was not present.")
+			return
+		}
+		if s.applyFault(w, r) {
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
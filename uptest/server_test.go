@@ -0,0 +1,232 @@
+package uptest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmpa-io/up-go"
+)
+
+var (
+	testStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func transactionIDs(txns []up.TransactionDataWrapper) (ids []string) {
+	for _, t := range txns {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func newTestServer(t *testing.T) (*Server, *up.Client) {
+	t.Helper()
+	s := NewServer(
+		WithAccounts(
+			NewAccount().ID("spending").DisplayName("spending").Build(),
+			NewAccount().ID("savings").DisplayName("savings").Type(up.AccountTypeSaver).Build(),
+		),
+		WithCategories(
+			NewCategory("good-life").Children("restaurants-and-cafes").Build(),
+			NewCategory("restaurants-and-cafes").Parent("good-life").Build(),
+		),
+	)
+	t.Cleanup(s.Close)
+	for i := 0; i < 25; i++ {
+		account := "spending"
+		if i%5 == 0 {
+			account = "savings"
+		}
+		s.AddTransactions(NewTransaction().
+			ID(fmt.Sprintf("txn-%02d", i)).
+			Account(account).
+			CreatedAt(testStart.AddDate(0, 0, i)).
+			Build())
+	}
+	s.AddTransactions(NewTransaction().
+		ID("tagged").
+		Account("spending").
+		CreatedAt(testStart.AddDate(0, 0, 30)).
+		Tags("coffee").
+		Build())
+	c, err := s.NewClient(context.Background())
+	if err != nil {
+		t.Fatalf("NewClient() returned an error; error=%v", err)
+	}
+	return s, c
+}
+
+func Test_Server_transactions(t *testing.T) {
+	tests := map[string]struct {
+		account string
+		options []up.ListTransactionsOption
+		want    []string
+		err     string
+	}{
+		"paginates every transaction, newest first": {
+			options: []up.ListTransactionsOption{up.ListTransactionsOptionPageSize(4)},
+			want: append([]string{"tagged"}, func() (ids []string) {
+				for i := 24; i >= 0; i-- {
+					ids = append(ids, fmt.Sprintf("txn-%02d", i))
+				}
+				return ids
+			}()...),
+		},
+		"filter by account": {
+			account: "savings",
+			options: []up.ListTransactionsOption{up.ListTransactionsOptionPageSize(2)},
+			want:    []string{"txn-20", "txn-15", "txn-10", "txn-05", "txn-00"},
+		},
+		"filter by since and until": {
+			options: []up.ListTransactionsOption{
+				up.ListTransactionsOptionSince(testStart.AddDate(0, 0, 3)),
+				up.ListTransactionsOptionUntil(testStart.AddDate(0, 0, 6)),
+			},
+			want: []string{"txn-05", "txn-04", "txn-03"},
+		},
+		"filter by tag": {
+			options: []up.ListTransactionsOption{up.ListTransactionsOptionTag("coffee")},
+			want:    []string{"tagged"},
+		},
+		"unknown account": {
+			account: "missing",
+			err:     "status_code=404",
+		},
+		"invalid page size": {
+			options: []up.ListTransactionsOption{up.ListTransactionsOptionPageSize(1000)},
+			err:     "status_code=400",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, c := newTestServer(t)
+			var got []up.TransactionDataWrapper
+			var err error
+			if tt.account != "" {
+				got, err = c.ListTransactionsByAccount(context.Background(), tt.account, tt.options...)
+			} else {
+				got, err = c.ListTransactions(context.Background(), tt.options...)
+			}
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("list returned an unexpected error; want=%v, got=%v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("list returned an error; error=%v", err)
+			}
+			if ids := transactionIDs(got); !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("list returned unexpected transactions;\nwant=%v\ngot=%v\n", tt.want, ids)
+			}
+		})
+	}
+}
+
+func Test_Server_accounts(t *testing.T) {
+	_, c := newTestServer(t)
+	got, err := c.ListAccounts(context.Background(),
+		up.ListAccountsOptionPageSize(1),
+		up.ListAccountsOptionFilterAccountType(up.AccountTypeSaver),
+	)
+	if err != nil {
+		t.Fatalf("ListAccounts() returned an error; error=%v", err)
+	}
+	if len(got) != 1 || got[0].DisplayName != "savings" {
+		t.Errorf("ListAccounts() returned unexpected accounts; got=%+v", got)
+	}
+	if _, err := c.GetAccount(context.Background(), "spending"); err != nil {
+		t.Errorf("GetAccount() returned an error; error=%v", err)
+	}
+}
+
+func Test_Server_mutations(t *testing.T) {
+	s, c := newTestServer(t)
+	ctx := context.Background()
+
+	// tags.
+	if err := c.AddTagsToTransaction(ctx, "txn-01", []string{"lunch", "work"}); err != nil {
+		t.Fatalf("AddTagsToTransaction() returned an error; error=%v", err)
+	}
+	if err := c.RemoveTagsFromTransaction(ctx, "tagged", []string{"coffee"}); err != nil {
+		t.Fatalf("RemoveTagsFromTransaction() returned an error; error=%v", err)
+	}
+	txn, _ := s.Transaction("txn-01")
+	if got, want := txn.Relationships.Tags.Data, []up.Object{{Type: "tags", ID: "lunch"}, {Type: "tags", ID: "work"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected tags after adding; want=%v, got=%v", want, got)
+	}
+	tags, err := c.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("ListTags() returned an error; error=%v", err)
+	}
+	var tagIDs []string
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	if want := []string{"lunch", "work"}; !reflect.DeepEqual(tagIDs, want) {
+		t.Errorf("ListTags() returned unexpected tags; want=%v, got=%v", want, tagIDs)
+	}
+	if err := c.AddTagsToTransaction(ctx, "txn-01", []string{"a", "b", "c", "d", "e"}); err == nil {
+		t.Errorf("AddTagsToTransaction() didn't return an error for too many tags")
+	}
+
+	// categories.
+	if err := c.SetTransactionCategory(ctx, "txn-02", "restaurants-and-cafes"); err != nil {
+		t.Fatalf("SetTransactionCategory() returned an error; error=%v", err)
+	}
+	txn, _ = s.Transaction("txn-02")
+	if got := txn.Relationships.ParentCategory.Data.ID; got != "good-life" {
+		t.Errorf("unexpected parent category; want=good-life, got=%v", got)
+	}
+	if err := c.SetTransactionCategory(ctx, "txn-02", "missing"); err == nil {
+		t.Errorf("SetTransactionCategory() didn't return an error for a missing category")
+	}
+	got, err := c.ListTransactions(ctx, up.ListTransactionsOptionCategory("good-life"))
+	if err != nil {
+		t.Fatalf("ListTransactions() returned an error; error=%v", err)
+	}
+	if ids := transactionIDs(got); !reflect.DeepEqual(ids, []string{"txn-02"}) {
+		t.Errorf("ListTransactions() returned unexpected transactions; got=%v", ids)
+	}
+}
+
+func Test_Server_errors(t *testing.T) {
+	s, c := newTestServer(t)
+	ctx := context.Background()
+
+	// a wrong token is rejected.
+	if _, err := up.New(ctx, "wrong",
+		up.WithEndpoint(s.Endpoint()),
+		up.WithHttpClient(s.Client()),
+	); err == nil || !strings.Contains(err.Error(), "status_code=401") {
+		t.Errorf("New() didn't return a 401 for a wrong token; error=%v", err)
+	}
+
+	// faults are applied as many times as asked.
+	s.InjectFault(Fault{Path: "/accounts", StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second, Times: 1})
+	_, err := c.ListAccounts(ctx)
+	var errResp up.ErrSenderInvalidResponse
+	if !errors.As(err, &errResp) || errResp.StatusCode() != http.StatusTooManyRequests {
+		t.Errorf("ListAccounts() didn't return the injected fault; error=%v", err)
+	}
+	if _, err := c.ListAccounts(ctx); err != nil {
+		t.Errorf("ListAccounts() returned an error once the fault was used; error=%v", err)
+	}
+
+	// latency respects the request's context.
+	s.InjectFault(Fault{Method: http.MethodGet, Latency: time.Minute, StatusCode: http.StatusServiceUnavailable})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Ping(timeoutCtx); err == nil {
+		t.Errorf("Ping() didn't return an error while the fault was injected")
+	}
+	s.ClearFaults()
+	if _, err := c.Ping(ctx); err != nil {
+		t.Errorf("Ping() returned an error once faults were cleared; error=%v", err)
+	}
+}